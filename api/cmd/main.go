@@ -1,9 +1,14 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"log"
 	"net/http"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"2gis-calm-map/api/config"
 	docs "2gis-calm-map/api/docs"
@@ -15,6 +20,7 @@ import (
 	"2gis-calm-map/api/internal/db"
 	"2gis-calm-map/api/internal/handler"
 	"2gis-calm-map/api/internal/middleware"
+	"2gis-calm-map/api/internal/service"
 )
 
 // @title 2gis-calm-map API
@@ -29,7 +35,108 @@ func main() {
 	cfg := config.LoadConfig()
 	db.Init(cfg)
 
-	r := gin.Default()
+	// Справочники типов и удобств: до разовых команд (импорт проверяет типы по справочнику) и до фоновых
+	// пересчётов, т.к. миграция меняет organization_type у организаций
+	if err := service.NewOrganizationTypeService().Sync(); err != nil {
+		log.Println("warn: failed to sync organization types:", err)
+	}
+	if err := service.NewAmenityService().Seed(); err != nil {
+		log.Println("warn: failed to seed amenities:", err)
+	}
+
+	// Офлайн-геокодер по локальному справочнику адресов (CSV), если задан GAZETTEER_PATH
+	if path := os.Getenv("GAZETTEER_PATH"); path != "" {
+		g, err := service.LoadGazetteer(path)
+		if err != nil {
+			log.Println("warn: failed to load gazetteer:", err)
+		} else {
+			service.SetGeocoder(g)
+			log.Printf("gazetteer loaded: %d addresses", g.Len())
+		}
+	}
+
+	// Разовые команды вместо запуска сервера: app geocode-backfill | app import-organizations ...
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "geocode-backfill":
+			filled, total, err := service.NewOrganizationService().BackfillCoordinates()
+			if err != nil {
+				log.Fatal("geocode backfill failed: ", err)
+			}
+			log.Printf("geocode backfill: %d of %d organizations without coordinates filled", filled, total)
+		case "import-organizations":
+			importOrganizations(os.Args[2:])
+		default:
+			log.Fatalf("unknown command %q (available: geocode-backfill, import-organizations)", os.Args[1])
+		}
+		return
+	}
+
+	// Взвешенные агрегаты для отзывов, оставленных до их появления. Синхронно, до приёма запросов:
+	// новый отзыв заполняет weight_sum, и строка с частично посчитанным агрегатом backfill'ом уже не нашлась бы
+	if err := service.NewOrganizationParamsService().BackfillSensitivity(); err != nil {
+		log.Println("warn: failed to backfill sensitivity aggregates:", err)
+	}
+
+	// Достраиваем векторы похожести для организаций, у которых их ещё нет (в фоне, не блокируя старт)
+	go func() {
+		n, err := service.NewOrganizationSimilarityService().BackfillMissing()
+		if err != nil {
+			log.Println("warn: failed to backfill organization vectors:", err)
+			return
+		}
+		if n > 0 {
+			log.Printf("organization vectors backfilled: %d", n)
+		}
+	}()
+
+	// Нормализованные адреса для организаций, созданных до появления нечёткого поиска
+	go func() {
+		n, err := service.NewOrganizationService().BackfillNormalizedAddresses()
+		if err != nil {
+			log.Println("warn: failed to backfill normalized addresses:", err)
+			return
+		}
+		if n > 0 {
+			log.Printf("normalized addresses backfilled: %d", n)
+		}
+	}()
+
+	// Перцентили: полный пересчёт при старте, затем пересчёт изменившихся типов (по умолчанию раз в 30 секунд)
+	pctInterval := 30 * time.Second
+	if v, err := time.ParseDuration(os.Getenv("PERCENTILE_INTERVAL")); err == nil && v > 0 {
+		pctInterval = v
+	}
+	go service.NewOrganizationPercentileService().RunWorker(pctInterval)
+
+	// Максимальный размер пачки для POST /organization/params/average/batch (по умолчанию 200)
+	if v, err := strconv.Atoi(os.Getenv("PARAMS_BATCH_MAX_SIZE")); err == nil {
+		handler.SetParamsBatchMaxSize(v)
+	}
+
+	// Лидерборды: полный расчёт при старте, затем пересборка изменившихся типов (по умолчанию раз в минуту)
+	lbInterval := time.Minute
+	if v, err := time.ParseDuration(os.Getenv("LEADERBOARD_INTERVAL")); err == nil && v > 0 {
+		lbInterval = v
+	}
+	go service.NewLeaderboardService().RunWorker(lbInterval)
+
+	// Периодическая офлайн-пересборка рекомендаций (по умолчанию раз в час)
+	recInterval := time.Hour
+	if v, err := time.ParseDuration(os.Getenv("RECOMMENDATIONS_INTERVAL")); err == nil && v > 0 {
+		recInterval = v
+	}
+	go service.NewRecommendationService().RunPeriodic(recInterval)
+
+	r := gin.New()
+	// Как gin.Default, но http.ErrAbortHandler (обрыв потоковой выгрузки) уходит в net/http,
+	// и соединение сбрасывается вместо корректного завершения неполного ответа.
+	r.Use(gin.Logger(), gin.CustomRecovery(func(c *gin.Context, err any) {
+		if err == http.ErrAbortHandler {
+			panic(err)
+		}
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
 
 	// Simple CORS (allow all) – adjust for production.
 	r.Use(func(c *gin.Context) {
@@ -66,6 +173,25 @@ func main() {
 	r.POST("/register", handler.Register)
 	r.POST("/login", handler.Login)
 	r.GET("/users", handler.GetUsers)
+	r.GET("/me/recommendations", middleware.JWTAuth(), handler.GetMyRecommendations)
+	r.GET("/me/organizations", middleware.JWTAuth(), handler.GetMyOrganizations)
+	r.GET("/me/claims", middleware.JWTAuth(), handler.GetMyOrganizationClaims)
+	r.GET("/me/notifications", middleware.JWTAuth(), handler.GetMyNotifications)
+	r.POST("/me/notifications/:notification_id/read", middleware.JWTAuth(), handler.MarkNotificationRead)
+	r.GET("/claims", middleware.JWTAuth(), handler.ListOrganizationClaims)
+	r.POST("/claims/:claim_id/approve", middleware.JWTAuth(), handler.ApproveOrganizationClaim)
+	r.POST("/claims/:claim_id/reject", middleware.JWTAuth(), handler.RejectOrganizationClaim)
+	r.GET("/leaderboards/:type", handler.GetLeaderboard)
+	r.GET("/organization-types", handler.GetOrganizationTypes)
+	r.GET("/amenities", handler.GetAmenities)
+	r.GET("/export/organizations.geojson", handler.ExportOrganizationsGeoJSON)
+	r.GET("/export/organizations.kml", handler.ExportOrganizationsKML)
+	r.POST("/brands", middleware.JWTAuth(), handler.CreateBrand)
+	r.GET("/brands", handler.ListBrands)
+	r.GET("/brands/:brand_id", handler.GetBrand)
+	r.PATCH("/brands/:brand_id", middleware.JWTAuth(), handler.PatchBrand)
+	r.POST("/brands/:brand_id/organizations", middleware.JWTAuth(), handler.AttachBrandOrganization)
+	r.DELETE("/brands/:brand_id/organizations/:organization_id", middleware.JWTAuth(), handler.DetachBrandOrganization)
 	r.POST("/user-params", middleware.JWTAuth(), handler.CreateUserParams)
 	r.GET("/user-params/:user_id", middleware.JWTAuth(), handler.GetUserParams)
 	r.PATCH("/user-params/:user_id", middleware.JWTAuth(), handler.PatchUserParams)
@@ -76,11 +202,30 @@ func main() {
 	r.POST("/organization/params/average", handler.GetOrganizationParamsAverage)
 	r.POST("/organization/params/average/by-type", handler.GetOrganizationsParamsAverageByType)
 	r.POST("/organization/params/average/with-info", handler.GetOrganizationParamsAverageWithOrganizationInfo)
+	r.POST("/organization/params/average/batch", handler.GetOrganizationParamsAverageBatch)
+	r.GET("/organization/search", handler.SearchOrganizations)
+	r.GET("/organization/compare", middleware.OptionalJWTAuth(), handler.CompareOrganizations)
 	r.POST("/organization/comment", middleware.JWTAuth(), handler.CreateOrganizationComment)
+	r.POST("/organization/import", middleware.JWTAuth(), handler.ImportOrganizations)
 	r.GET("/organization/:organization_id/comments", middleware.JWTAuth(), handler.GetOrganizationComments)
+	r.PUT("/organization/:organization_id/comments/:comment_id/response", middleware.JWTAuth(), handler.PutOrganizationCommentResponse)
+	r.DELETE("/organization/:organization_id/comments/:comment_id/response", middleware.JWTAuth(), handler.DeleteOrganizationCommentResponse)
 	r.POST("/organization/:organization_id/map/upload", middleware.JWTAuth(), handler.UploadOrganizationMap)
 	r.POST("/organization/:organization_id/picture/upload", middleware.JWTAuth(), handler.UploadOrganizationPicture)
 	r.GET("/organization/:organization_id/image/:kind", handler.GetOrganizationImageHandler)
+	r.GET("/organization/:organization_id/similar", handler.GetSimilarOrganizations)
+	r.GET("/organization/:organization_id/quiet-hours.ics", handler.GetOrganizationQuietHoursCalendar)
+	r.POST("/organization/:organization_id/claims", middleware.JWTAuth(), handler.CreateOrganizationClaim)
+	r.POST("/organization/:organization_id/status", middleware.JWTAuth(), handler.SetOrganizationStatus)
+	r.POST("/organization/:organization_id/restore", middleware.JWTAuth(), handler.RestoreOrganization)
+	r.PATCH("/organization/:organization_id", middleware.JWTAuth(), handler.PatchOrganizationByID)
+	r.DELETE("/organization/:organization_id", middleware.JWTAuth(), handler.DeleteOrganization)
+	r.GET("/organization/:organization_id/amenities", middleware.OptionalJWTAuth(), handler.GetOrganizationAmenities)
+	r.PUT("/organization/:organization_id/amenities", middleware.JWTAuth(), handler.PutOrganizationAmenities)
+	r.POST("/organization/:organization_id/amenities/:amenity/vote", middleware.JWTAuth(), handler.VoteOrganizationAmenity)
+	r.GET("/organization/:organization_id/history", middleware.JWTAuth(), handler.GetOrganizationHistory)
+	r.POST("/organization/:organization_id/history/:revision_id/revert", middleware.JWTAuth(), handler.RevertOrganizationRevision)
+	r.GET("/organization/:organization_id/dashboard", middleware.JWTAuth(), handler.GetOrganizationDashboard)
 
 	log.Println("start at :8080")
 	if err := r.Run(":8080"); err != nil {
@@ -88,3 +233,51 @@ func main() {
 	}
 	// test commit
 }
+
+// importOrganizations: app import-organizations -owner admin@example.com [-format csv|geojson] [-dry-run] [-atomic] FILE
+// Печатает отчёт (JSON) в stdout; код выхода 1, если есть невалидные или неудавшиеся строки.
+func importOrganizations(args []string) {
+	fs := flag.NewFlagSet("import-organizations", flag.ExitOnError)
+	owner := fs.String("owner", "", "email of the admin who will own the imported organizations")
+	format := fs.String("format", "", "csv | geojson (default: by file extension)")
+	dryRun := fs.Bool("dry-run", false, "validate only, create nothing")
+	atomic := fs.Bool("atomic", false, "all or nothing: abort on any invalid row, insert in one transaction")
+	_ = fs.Parse(args)
+	if fs.NArg() != 1 || *owner == "" {
+		log.Fatal("usage: import-organizations -owner EMAIL [-format csv|geojson] [-dry-run] [-atomic] FILE")
+	}
+
+	user, err := service.NewUserService().GetByEmail(*owner)
+	if err != nil {
+		log.Fatalf("owner %q: %v", *owner, err)
+	}
+	if user.Role != "admin" {
+		log.Fatalf("owner %q is not an admin", *owner)
+	}
+	path := fs.Arg(0)
+	if *format == "" {
+		*format = service.ImportFormatOf(path, "")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	rows, err := service.ParseImport(*format, f)
+	if err != nil {
+		log.Fatal("import failed: ", err)
+	}
+	res, err := service.NewOrganizationService().Import(rows, service.ImportOptions{OwnerID: user.ID, DryRun: *dryRun, Atomic: *atomic})
+	if err != nil {
+		log.Fatal("import failed: ", err)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(res)
+	log.Printf("import: %d rows, %d valid, %d created, %d duplicates, %d invalid, %d failed (dry run: %v, aborted: %v)",
+		res.Total, res.Valid, res.Created, res.Duplicates, res.Invalid, res.Failed, res.DryRun, res.Aborted)
+	if res.Invalid > 0 || res.Failed > 0 {
+		f.Close()
+		os.Exit(1)
+	}
+}
@@ -15,57 +15,25 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/login": {
-            "post": {
-                "description": "Authenticates user and returns JWT token",
-                "consumes": [
-                    "application/json"
-                ],
+        "/amenities": {
+            "get": {
+                "description": "Справочник сенсорных удобств (тихая комната, регулируемый свет, наушники и т.п.). slug используется при объявлении удобств и в фильтрах поиска (amenities). Публично.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
-                ],
-                "summary": "User login",
-                "parameters": [
-                    {
-                        "description": "Login credentials (email \u0026 password)",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.LoginRequest"
-                        }
-                    }
+                    "amenities"
                 ],
+                "summary": "Amenities catalog",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.LoginResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid input",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/handler.AmenitiesResponse"
                         }
                     },
                     "500": {
-                        "description": "Server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -76,25 +44,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/organization": {
+        "/brands": {
             "get": {
-                "description": "Публичный доступ: возвращает организацию текущего владельца (если авторизован) или 404 если нет. (Упростили доступ — без ограничения ролей)",
+                "description": "Бренды с числом активных филиалов. Публично.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "organization"
+                    "brands"
+                ],
+                "summary": "List brands",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only brands of this owner",
+                        "name": "owner_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max items (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get organization of authenticated owner OR (public) first organization of that owner",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Organization"
+                            "$ref": "#/definitions/handler.BrandsResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -105,12 +102,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create organization for current user. Ограничение 1:1 действует ТОЛЬКО для role=owner. Админы (role=admin) могут создавать неограниченно.",
+                "description": "Бренд объединяет филиалы сети. Владелец бренда управляет всеми его организациями и может создавать новые филиалы (POST /organization с brand_id) без ограничения «одна организация на владельца». owner/admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -118,17 +110,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "organization"
+                    "brands"
                 ],
-                "summary": "Create organization",
+                "summary": "Create brand (chain)",
                 "parameters": [
                     {
-                        "description": "Organization data",
+                        "description": "Brand",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.OrganizationCreateRequest"
+                            "$ref": "#/definitions/handler.BrandCreateRequest"
                         }
                     }
                 ],
@@ -136,7 +128,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Organization"
+                            "$ref": "#/definitions/model.Brand"
                         }
                     },
                     "400": {
@@ -148,15 +140,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
@@ -166,15 +149,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -184,41 +158,38 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "patch": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Partially update organization (owner/admin)",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/brands/{brand_id}": {
+            "get": {
+                "description": "Бренд, его активные филиалы и оценки по факторам, агрегированные по всем филиалам (сумма оценок / число оценок, а также взвешенное по чувствительности среднее). Публично.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "organization"
+                    "brands"
                 ],
-                "summary": "Update organization",
+                "summary": "Brand with branches and aggregated scores",
                 "parameters": [
                     {
-                        "description": "Fields to update",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.OrganizationUpdateRequest"
-                        }
+                        "type": "integer",
+                        "description": "Brand ID",
+                        "name": "brand_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Organization"
+                            "$ref": "#/definitions/handler.BrandResponse"
                         }
                     },
                     "400": {
@@ -230,24 +201,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -267,16 +220,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/organization/comment": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Создаёт комментарий. user_id берётся из токена автоматически. Каждый не-nil и \u003e0 value обновляет агрегаты (sum,count,avg).",
+            },
+            "patch": {
+                "description": "Владелец бренда или admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -284,25 +230,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "organization-comments"
+                    "brands"
                 ],
-                "summary": "Create comment for organization with optional parameter ratings",
+                "summary": "Update brand",
                 "parameters": [
                     {
-                        "description": "Create comment",
+                        "type": "integer",
+                        "description": "Brand ID",
+                        "name": "brand_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.OrganizationCommentCreateRequest"
+                            "$ref": "#/definitions/handler.BrandUpdateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.OrganizationCommentCreateResponse"
+                            "$ref": "#/definitions/model.Brand"
                         }
                     },
                     "400": {
@@ -314,8 +267,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -323,8 +276,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -341,12 +294,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/organization/params/average": {
+        "/brands/{brand_id}/organizations": {
             "post": {
-                "description": "Returns (avg(param1)+...)/N for specified params. Public access (без проверки роли).",
+                "description": "Делает организацию филиалом бренда. Нужны права на бренд (владелец или admin) и на организацию (её владелец или admin).",
                 "consumes": [
                     "application/json"
                 ],
@@ -354,17 +312,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "organization-params"
+                    "brands"
                 ],
-                "summary": "Compute average across selected organization params",
+                "summary": "Add organization to brand",
                 "parameters": [
                     {
-                        "description": "Organization params average request",
+                        "type": "integer",
+                        "description": "Brand ID",
+                        "name": "brand_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Organization",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.OrganizationParamsAverageRequest"
+                            "$ref": "#/definitions/handler.BrandAttachRequest"
                         }
                     }
                 ],
@@ -372,7 +337,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.OrganizationParamsAverageResponse"
+                            "$ref": "#/definitions/model.Organization"
                         }
                     },
                     "400": {
@@ -384,8 +349,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -393,8 +358,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -402,8 +367,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -411,38 +385,45 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/organization/params/average/by-type": {
-            "post": {
-                "description": "For every organization of a specified type computes (avg(p1)+...)/N and returns only those with average \u003e threshold (default 3.0).",
-                "consumes": [
-                    "application/json"
-                ],
+        "/brands/{brand_id}/organizations/{organization_id}": {
+            "delete": {
+                "description": "Владелец бренда, владелец организации или admin.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "organization-params"
+                    "brands"
                 ],
-                "summary": "Compute averages for each organization of given type",
+                "summary": "Remove organization from brand",
                 "parameters": [
                     {
-                        "description": "Request",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.OrganizationsParamsAverageByTypeRequest"
-                        }
+                        "type": "integer",
+                        "description": "Brand ID",
+                        "name": "brand_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.OrganizationsParamsAverageByTypeResponse"
+                            "$ref": "#/definitions/model.Organization"
                         }
                     },
                     "400": {
@@ -454,8 +435,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -463,47 +444,55 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
         },
-        "/organization/params/average/with-info": {
-            "post": {
-                "description": "Как /organization/params/average, но дополняет адресом/типом/координатами и путями изображений. Публично.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/claims": {
+            "get": {
+                "description": "Все заявки (по умолчанию — ожидающие рассмотрения) с историей статусов. Только admin.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "organization-params"
+                    "organization-claims"
                 ],
-                "summary": "Compute average and return organization info",
+                "summary": "List organization claims (admin)",
                 "parameters": [
                     {
-                        "description": "Request",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.OrganizationParamsWithOrgRequest"
-                        }
+                        "type": "string",
+                        "description": "pending (default) | approved | rejected | all",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.OrganizationParamsWithOrgResponse"
+                            "$ref": "#/definitions/handler.OrganizationClaimsResponse"
                         }
                     },
                     "400": {
@@ -515,8 +504,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -533,12 +522,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/organization/public/by-address": {
+        "/claims/{claim_id}/approve": {
             "post": {
-                "description": "Возвращает организацию (id и основные поля) по точному адресу. Публично.",
+                "description": "Передаёт организацию заявителю (OwnerID), остальные ожидающие заявки на неё отклоняются. Заявитель, прежний владелец и отклонённые заявители получают уведомления. Только admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -546,17 +540,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "organization"
+                    "organization-claims"
                 ],
-                "summary": "Public organization lookup by address",
+                "summary": "Approve organization claim (admin)",
                 "parameters": [
                     {
-                        "description": "Address lookup",
+                        "type": "integer",
+                        "description": "Claim ID",
+                        "name": "claim_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review note",
                         "name": "input",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.OrganizationByAddressRequest"
+                            "$ref": "#/definitions/handler.OrganizationClaimReviewRequest"
                         }
                     }
                 ],
@@ -564,7 +564,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.OrganizationByAddressResponse"
+                            "$ref": "#/definitions/model.OrganizationClaim"
                         }
                     },
                     "400": {
@@ -576,54 +576,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/organization/{organization_id}/comments": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Возвращает список комментариев организации с автором и средней оценкой.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "organization-comments"
-                ],
-                "summary": "List comments for organization",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Organization ID",
-                        "name": "organization_id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.OrganizationCommentListResponse"
-                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -631,8 +594,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -649,40 +612,49 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/organization/{organization_id}/image/{kind}": {
-            "get": {
-                "description": "Возвращает файл изображения по типу (map | picture).",
+        "/claims/{claim_id}/reject": {
+            "post": {
+                "description": "Отклоняет заявку, заявитель получает уведомление с комментарием. Только admin.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
-                    "application/octet-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "organization-media"
+                    "organization-claims"
                 ],
-                "summary": "Get organization image (map or picture)",
+                "summary": "Reject organization claim (admin)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Organization ID",
-                        "name": "organization_id",
+                        "description": "Claim ID",
+                        "name": "claim_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Kind (map|picture)",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
+                        "description": "Review note",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationClaimReviewRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/model.OrganizationClaim"
                         }
                     },
                     "400": {
@@ -694,8 +666,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -703,8 +675,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -712,8 +684,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -721,51 +702,116 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/organization/{organization_id}/map/upload": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Загрузка файла карты организации (png/jpg/jpeg/webp/gif). Требует роль owner/admin.",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                ]
+            }
+        },
+        "/export/organizations.geojson": {
+            "get": {
+                "description": "Потоковая выгрузка организаций с координатами в GeoJSON FeatureCollection (например, для QGIS): точка, тип, статус, бренд и средние по каждому фактору (\u003cparam\u003e — среднее, null если оценок нет; \u003cparam\u003e_count — число оценок) в properties. Фильтры те же, что у GET /organization/search; q необязателен. Закрытые организации исключаются, если не передан include_archived. Публично.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "organization-media"
+                    "organization"
                 ],
-                "summary": "Upload organization map image",
+                "summary": "Export organizations as GeoJSON",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Organization type (slug or name)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Per-factor minimums, e.g. smell:4,lighting:3.5",
+                        "name": "min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "plain (default) | sensitivity",
+                        "name": "aggregate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latitude of the center (with lon and radius)",
+                        "name": "lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude of the center",
+                        "name": "lon",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Radius in meters",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only organizations open now",
+                        "name": "open_now",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only organizations open at this moment (RFC3339)",
+                        "name": "open_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only organizations in quiet hours now",
+                        "name": "quiet_now",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only organizations in quiet hours at this moment (RFC3339)",
+                        "name": "quiet_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include temporarily/permanently closed organizations",
+                        "name": "include_archived",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Organization ID",
-                        "name": "organization_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only branches of this brand (id)",
+                        "name": "brand",
+                        "in": "query"
                     },
                     {
-                        "type": "file",
-                        "description": "Image file",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "type": "string",
+                        "description": "Comma-separated required amenity slugs (see GET /amenities)",
+                        "name": "amenities",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "GeoJSON FeatureCollection",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -777,26 +823,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -807,48 +835,107 @@ const docTemplate = `{
                 }
             }
         },
-        "/organization/{organization_id}/picture/upload": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Загрузка основной картинки организации. Требует роль owner/admin.",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+        "/export/organizations.kml": {
+            "get": {
+                "description": "Та же выгрузка, что /export/organizations.geojson, в формате KML: Placemark с точкой, адресом, описанием и атрибутами (включая средние по факторам) в ExtendedData. Публично.",
                 "produces": [
-                    "application/json"
+                    "application/vnd.google-earth.kml+xml"
                 ],
                 "tags": [
-                    "organization-media"
+                    "organization"
                 ],
-                "summary": "Upload organization picture image",
+                "summary": "Export organizations as KML",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Organization ID",
-                        "name": "organization_id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Search text",
+                        "name": "q",
+                        "in": "query"
                     },
                     {
-                        "type": "file",
-                        "description": "Image file",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "type": "string",
+                        "description": "Organization type (slug or name)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Per-factor minimums, e.g. smell:4,lighting:3.5",
+                        "name": "min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "plain (default) | sensitivity",
+                        "name": "aggregate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latitude of the center (with lon and radius)",
+                        "name": "lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude of the center",
+                        "name": "lon",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Radius in meters",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only organizations open now",
+                        "name": "open_now",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only organizations open at this moment (RFC3339)",
+                        "name": "open_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only organizations in quiet hours now",
+                        "name": "quiet_now",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only organizations in quiet hours at this moment (RFC3339)",
+                        "name": "quiet_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include temporarily/permanently closed organizations",
+                        "name": "include_archived",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only branches of this brand (id)",
+                        "name": "brand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated required amenity slugs (see GET /amenities)",
+                        "name": "amenities",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "KML document",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -860,26 +947,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -890,39 +959,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/register": {
-            "post": {
-                "description": "Creates a new user and returns a JWT token",
-                "consumes": [
-                    "application/json"
-                ],
+        "/leaderboards/{type}": {
+            "get": {
+                "description": "Топ спокойных мест по типу для типовых наборов параметров (overall, quiet, sensory, navigation, staff). Списки предрасчитываются фоновым процессом после изменения оценок, запрос не сканирует организации. Публично.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "leaderboards"
                 ],
-                "summary": "Register new user",
+                "summary": "Precomputed top organizations of a type",
                 "parameters": [
                     {
-                        "description": "User registration data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.RegisterRequest"
-                        }
+                        "type": "string",
+                        "description": "Organization type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preset name; all presets if omitted",
+                        "name": "preset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max items per board (default 10, max 20)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RegisterResponse"
+                            "$ref": "#/definitions/handler.LeaderboardResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -931,7 +1007,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -942,14 +1018,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user-params": {
+        "/login": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Saves user evaluation parameters. Requires JWT token.",
+                "description": "Authenticates user and returns JWT token",
                 "consumes": [
                     "application/json"
                 ],
@@ -957,17 +1028,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-params"
+                    "auth"
                 ],
-                "summary": "Create user parameters",
+                "summary": "User login",
                 "parameters": [
                     {
-                        "description": "User parameters",
+                        "description": "Login credentials (email \u0026 password)",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateUserParamsRequest"
+                            "$ref": "#/definitions/handler.LoginRequest"
                         }
                     }
                 ],
@@ -975,7 +1046,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserParams"
+                            "$ref": "#/definitions/handler.LoginResponse"
                         }
                     },
                     "400": {
@@ -1008,39 +1079,82 @@ const docTemplate = `{
                 }
             }
         },
-        "/user-params/{user_id}": {
+        "/me/claims": {
             "get": {
+                "description": "Заявки текущего пользователя с историей статусов.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization-claims"
+                ],
+                "summary": "My organization claims",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationClaimsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Returns user evaluation parameters. Requires JWT token.",
+                ]
+            }
+        },
+        "/me/notifications": {
+            "get": {
+                "description": "Уведомления текущего пользователя (новые первыми).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user-params"
+                    "notifications"
                 ],
-                "summary": "Get user parameters by user id",
+                "summary": "My notifications",
                 "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only unread",
+                        "name": "unread",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Max items (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserParams"
+                            "$ref": "#/definitions/handler.NotificationsResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1048,8 +1162,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1058,7 +1172,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1066,52 +1180,44 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "patch": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Partially updates user evaluation parameters. Requires JWT token.",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/me/notifications/{notification_id}/read": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user-params"
+                    "notifications"
                 ],
-                "summary": "Partially update user parameters by user id",
+                "summary": "Mark notification as read",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
+                        "description": "Notification ID",
+                        "name": "notification_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Fields to update (any subset)",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.CreateUserParamsRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserParams"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1129,7 +1235,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Not found",
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1138,7 +1244,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1146,136 +1252,4569 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/users": {
+        "/me/organizations": {
             "get": {
+                "description": "Все организации текущего пользователя (включая филиалы его бренда), старые первыми. Для владельцев нескольких организаций — замена GET/PATCH /organization (они отвечают 409): выбрать id отсюда и работать через /organization/:organization_id.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "organization"
                 ],
-                "summary": "Get users",
+                "summary": "Organizations of the authenticated owner",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/handler.MyOrganizationsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "handler.CreateUserParamsRequest": {
-            "type": "object",
-            "properties": {
-                "appearance": {
-                    "type": "boolean"
-                },
-                "calmness": {
-                    "type": "boolean"
-                },
-                "intuitiveness": {
-                    "type": "boolean"
-                },
-                "lighting": {
-                    "type": "boolean"
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/me/recommendations": {
+            "get": {
+                "description": "Организации, которые высоко оценили пользователи с похожими предпочтениями (UserParams) и оценками, и на которые текущий пользователь ещё не оставлял отзыв. Модель пересчитывается периодически в фоне (RECOMMENDATIONS_INTERVAL).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recommendations"
+                ],
+                "summary": "Recommendations from similar reviewers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Max items (default 20, max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RecommendationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/organization": {
+            "get": {
+                "description": "Публичный доступ: возвращает организацию текущего владельца (если авторизован) или 404 если нет; 409, если у владельца несколько организаций (филиалы) — тогда список см. GET /me/organizations, а к конкретной обращайтесь по id. (Упростили доступ — без ограничения ролей)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Get organization of authenticated owner OR (public) first organization of that owner",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Organization"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create organization for current user. organization_type — slug или название из GET /organization-types. Ограничение 1:1 действует ТОЛЬКО для role=owner и не распространяется на филиалы собственного бренда (brand_id). Админы (role=admin) могут создавать неограниченно.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Create organization",
+                "parameters": [
+                    {
+                        "description": "Organization data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Partially update organization (owner/admin). organization_type — slug или название из GET /organization-types. status — active | temporarily_closed | closed_permanently (закрытые не участвуют в поиске и рейтингах). Если у владельца несколько организаций — 409, используйте PATCH /organization/:organization_id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Update organization",
+                "parameters": [
+                    {
+                        "description": "Fields to update",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/organization-types": {
+            "get": {
+                "description": "Справочник типов организаций: slug (хранится в organization_type), названия на русском и английском, родительская категория и число организаций этого типа. При создании/изменении организации тип можно передать как slug или локализованное название. Публично.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "List organization types",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationTypesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/comment": {
+            "post": {
+                "description": "Создаёт комментарий. user_id берётся из токена автоматически. Каждый не-nil и \u003e0 value обновляет агрегаты (sum,count,avg).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization-comments"
+                ],
+                "summary": "Create comment for organization with optional parameter ratings",
+                "parameters": [
+                    {
+                        "description": "Create comment",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationCommentCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationCommentCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/organization/compare": {
+            "get": {
+                "description": "Возвращает avg/count по всем параметрам, лучший и худший параметр для каждой организации. Если передан токен — добавляет персональную оценку по параметрам из UserParams. От 2 до 10 организаций.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization-params"
+                ],
+                "summary": "Side-by-side comparison of organizations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated organization IDs, e.g. 1,2,3",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "plain (default) | sensitivity",
+                        "name": "aggregate",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationCompareResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/import": {
+            "post": {
+                "description": "Импорт организаций из CSV (заголовок обязателен, разделитель «,» или «;»; колонки name, description, phone, website, address, lat, lon, organization_type, brand_id, opening_hours и quiet_hours в JSON) или GeoJSON FeatureCollection точек (те же имена в properties). Файл — multipart-поле file или тело запроса. Каждая строка проверяется как в POST /organization; дубли (тот же нормализованный адрес или точка ближе 25 м при совпадающем названии — среди существующих организаций и ранее в файле) пропускаются. По умолчанию строки создаются по отдельности с отчётом по каждой; atomic=true — всё или ничего в одной транзакции; dry_run=true — только проверка. Владелец созданных организаций — вызывающий admin.",
+                "consumes": [
+                    "multipart/form-data",
+                    "text/csv",
+                    "application/geo+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Bulk import organizations (admin)",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV or GeoJSON file",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv | geojson (default: by file extension or Content-Type)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate only",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "All or nothing",
+                        "name": "atomic",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/organization/params/average": {
+            "post": {
+                "description": "Returns (avg(param1)+...)/N for specified params with per-param breakdown (excluded params have reason not_rated). Public access (без проверки роли).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization-params"
+                ],
+                "summary": "Compute average across selected organization params",
+                "parameters": [
+                    {
+                        "description": "Organization params average request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationParamsAverageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationParamsAverageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/params/average/batch": {
+            "post": {
+                "description": "Для списка organization_ids возвращает среднее по params (или взвешенное по weights), а также avg/count по каждому выбранному параметру. Один запрос к БД. Максимальный размер пачки задаётся PARAMS_BATCH_MAX_SIZE (по умолчанию 200). Публично.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization-params"
+                ],
+                "summary": "Compute selected average for many organizations at once",
+                "parameters": [
+                    {
+                        "description": "Batch request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationParamsBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationParamsBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/params/average/by-type": {
+            "post": {
+                "description": "For every organization of a specified type computes (avg(p1)+...)/N and returns only those with average \u003e threshold (default 3.0).\nДополнительно: min_params — минимумы по отдельным параметрам (avg \u003e= value), min_reviews — минимальное число отзывов, sort/limit/offset, open_now/open_at — только открытые сейчас (или в момент open_at) по часам работы, quiet_now/quiet_at — только в «тихие часы», include_archived — включить закрытые организации, brand_id — только филиалы бренда, amenities — только с указанными удобствами (неоспоренными). Фильтрация и сортировка выполняются в SQL.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization-params"
+                ],
+                "summary": "Compute averages for each organization of given type",
+                "parameters": [
+                    {
+                        "description": "Request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationsParamsAverageByTypeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationsParamsAverageByTypeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/params/average/with-info": {
+            "post": {
+                "description": "Как /organization/params/average, но дополняет адресом/типом/координатами и путями изображений. Публично.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization-params"
+                ],
+                "summary": "Compute average and return organization info",
+                "parameters": [
+                    {
+                        "description": "Request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationParamsWithOrgRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationParamsWithOrgResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/public/by-address": {
+            "post": {
+                "description": "Возвращает организацию (id и основные поля) по адресу. Сначала точное совпадение, затем совпадение нормализованного адреса (регистр, пунктуация, сокращения «ул.», «пр-т», «д.», «к.» и т.п.) — поле match = exact | normalized. Если ничего не нашлось, 404 содержит до 5 похожих адресов (триграммное сходство) с оценкой similarity; кандидаты с тем же номером дома идут первыми. Публично.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Public organization lookup by address",
+                "parameters": [
+                    {
+                        "description": "Address lookup",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationByAddressRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationByAddressResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationByAddressNotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/search": {
+            "get": {
+                "description": "Полнотекстовый поиск по названию, адресу, типу (включая названия из справочника) и описанию с учётом русской морфологии. Последнее слово ищется по префиксу (автодополнение). Результаты отсортированы по релевантности. Фильтры комбинируются: type, min (минимумы по параметрам), lat/lon/radius, open_now/open_at, quiet_now/quiet_at, brand, amenities. Закрытые организации (status != active) исключаются, если не передан include_archived. Публично.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Full-text organization search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Organization type (slug or name)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated params; average over them is returned",
+                        "name": "params",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Per-factor minimums, e.g. smell:4,lighting:3.5",
+                        "name": "min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "plain (default) | sensitivity",
+                        "name": "aggregate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latitude of the center (with lon and radius)",
+                        "name": "lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude of the center",
+                        "name": "lon",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Radius in meters",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only organizations open now",
+                        "name": "open_now",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only organizations open at this moment (RFC3339)",
+                        "name": "open_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only organizations in quiet hours now",
+                        "name": "quiet_now",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only organizations in quiet hours at this moment (RFC3339)",
+                        "name": "quiet_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include temporarily/permanently closed organizations",
+                        "name": "include_archived",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only branches of this brand (id)",
+                        "name": "brand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated required amenity slugs (see GET /amenities)",
+                        "name": "amenities",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max items (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{organization_id}": {
+            "delete": {
+                "description": "Мягкое удаление: организация пропадает из всех публичных эндпоинтов, отзывы и агрегаты остаются и доступны админам (GET /organization/{id}/comments). Восстановление — POST /organization/{id}/restore (admin). Владелец организации или admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Delete organization (soft)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "То же, что PATCH /organization, но для конкретной организации: владелец организации, владелец её бренда (сети) или admin.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Update organization by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/organization/{organization_id}/amenities": {
+            "get": {
+                "description": "Удобства, объявленные владельцем, с числом подтверждений и опровержений от посетителей. С токеном — также собственный голос (my_vote). Публично.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "amenities"
+                ],
+                "summary": "Organization amenities",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationAmenitiesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Заменяет набор объявленных удобств. Голоса по убранным удобствам удаляются, по оставшимся — сохраняются. Владелец организации (или её бренда) либо admin.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "amenities"
+                ],
+                "summary": "Declare organization amenities",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Amenities",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationAmenitiesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationAmenitiesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/organization/{organization_id}/amenities/{amenity}/vote": {
+            "post": {
+                "description": "Посетитель подтверждает (confirmed=true) или оспаривает (false) объявленное удобство; повторный голос заменяет предыдущий. Голосовать могут только пользователи, оставившие отзыв об организации (иначе 403); владелец организации голосовать не может.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "amenities"
+                ],
+                "summary": "Confirm or dispute an amenity",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Amenity slug",
+                        "name": "amenity",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Vote",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.AmenityVoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationAmenityItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/organization/{organization_id}/claims": {
+            "post": {
+                "description": "Владелец (role=owner/admin) запрашивает управление существующей организацией, прикладывая подтверждение (evidence). Заявка получает статус pending, админы получают уведомление. Владелец с ролью owner может управлять только одной организацией.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization-claims"
+                ],
+                "summary": "Claim an existing organization",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Claim",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationClaimCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrganizationClaim"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/organization/{organization_id}/comments": {
+            "get": {
+                "description": "Возвращает список комментариев организации с автором, средней оценкой и ответом организации. Отзывы удалённой организации доступны только admin.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization-comments"
+                ],
+                "summary": "List comments for organization",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationCommentListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/organization/{organization_id}/comments/{comment_id}/response": {
+            "put": {
+                "description": "Ответ организации на отзыв: один на отзыв, повторный вызов редактирует текст. Только владелец организации (OwnerID) или admin. При первом ответе автор отзыва получает уведомление (kind=comment_response).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization-comments"
+                ],
+                "summary": "Reply to a review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reply",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationCommentResponseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrganizationCommentResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrganizationCommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Только владелец организации (OwnerID) или admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization-comments"
+                ],
+                "summary": "Delete reply to a review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/organization/{organization_id}/dashboard": {
+            "get": {
+                "description": "Аналитика отзывов организации: динамика числа отзывов по дням/неделям/месяцам (пустые периоды включены). Отзывы, оставленные до появления даты у отзывов (created_at = null), входят в total_reviews, но не в volume: их число отдаётся в undated_reviews и в динамике они не появятся никогда, средние по факторам в сравнении со средними по всем активным организациям того же типа, самые слабые факторы, частые слова в комментариях к факторам (всего и по каждому фактору) и последние отзывы. Владелец организации, владелец бренда или admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Owner analytics dashboard",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Volume bucket: day | week (default) | month",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of last periods (default 12, max 366)",
+                        "name": "periods",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Top words size (default 20, max 100)",
+                        "name": "words",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Recent reviews (default 10, max 50)",
+                        "name": "recent",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationDashboardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/organization/{organization_id}/history": {
+            "get": {
+                "description": "Журнал изменений организации (создание, правки, загрузка изображений, смена статуса, удаление/восстановление, откаты, передача владения по заявке — action=owner, изменение удобств — action=amenities): кто, когда, снимки полей до и после, список изменённых полей. Новые сверху. Владелец организации или admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Organization edit history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max items (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/organization/{organization_id}/history/{revision_id}/revert": {
+            "post": {
+                "description": "Восстанавливает поля организации в состояние после указанной ревизии (кроме принадлежности к бренду — она меняется только через привязку/отвязку бренда); тип организации приводится к текущему справочнику (409, если его там больше нет); сам откат записывается в историю новой ревизией (action=revert). Владелец организации или admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Revert organization to a revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision ID",
+                        "name": "revision_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/organization/{organization_id}/image/{kind}": {
+            "get": {
+                "description": "Возвращает файл изображения по типу (map | picture).",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "organization-media"
+                ],
+                "summary": "Get organization image (map or picture)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kind (map|picture)",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{organization_id}/map/upload": {
+            "post": {
+                "description": "Загрузка файла карты организации (png/jpg/jpeg/webp/gif). Требует роль owner/admin.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization-media"
+                ],
+                "summary": "Upload organization map image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/organization/{organization_id}/picture/upload": {
+            "post": {
+                "description": "Загрузка основной картинки организации. Требует роль owner/admin.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization-media"
+                ],
+                "summary": "Upload organization picture image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/organization/{organization_id}/quiet-hours.ics": {
+            "get": {
+                "description": "Расписание «тихих часов» организации в формате iCalendar (text/calendar) для подписки в календаре: еженедельные события с RRULE, даты-исключения исключены (EXDATE) или заданы отдельными событиями. Публично.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Quiet hours iCalendar feed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{organization_id}/restore": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Restore deleted organization (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/organization/{organization_id}/similar": {
+            "get": {
+                "description": "Ищет организации с похожим профилем оценок (11 параметров, нормализованы в [-1,1], неоценённые = 0). Векторы предрасчитываются при каждом новом отзыве. Публично.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization-params"
+                ],
+                "summary": "Similar calm places",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "euclidean (default) | cosine",
+                        "name": "metric",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only organizations of the same type",
+                        "name": "same_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Radius in meters around the organization",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only organizations open now",
+                        "name": "open_now",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only organizations open at this moment (RFC3339)",
+                        "name": "open_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only organizations in quiet hours now",
+                        "name": "quiet_now",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only organizations in quiet hours at this moment (RFC3339)",
+                        "name": "quiet_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max items (default 10, max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SimilarOrganizationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{organization_id}/status": {
+            "post": {
+                "description": "Архивация: temporarily_closed / closed_permanently убирают организацию из поиска, рейтингов и рекомендаций; отзывы и агрегаты сохраняются. active — вернуть. Владелец организации или admin.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Change organization status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "organization_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrganizationStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/register": {
+            "post": {
+                "description": "Creates a new user and returns a JWT token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Register new user",
+                "parameters": [
+                    {
+                        "description": "User registration data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RegisterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-params": {
+            "post": {
+                "description": "Saves user evaluation parameters. Requires JWT token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-params"
+                ],
+                "summary": "Create user parameters",
+                "parameters": [
+                    {
+                        "description": "User parameters",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateUserParamsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserParams"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/user-params/{user_id}": {
+            "get": {
+                "description": "Returns user evaluation parameters. Requires JWT token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-params"
+                ],
+                "summary": "Get user parameters by user id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserParams"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Partially updates user evaluation parameters. Requires JWT token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-params"
+                ],
+                "summary": "Partially update user parameters by user id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update (any subset)",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateUserParamsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserParams"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get users",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.User"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handler.AmenitiesResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Amenity"
+                    }
+                }
+            }
+        },
+        "handler.AmenityVoteRequest": {
+            "type": "object",
+            "required": [
+                "confirmed"
+            ],
+            "properties": {
+                "confirmed": {
+                    "description": "true — подтверждаю, false — оспариваю",
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.BrandAttachRequest": {
+            "type": "object",
+            "required": [
+                "organization_id"
+            ],
+            "properties": {
+                "organization_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.BrandCreateRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 5000
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "website": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "handler.BrandResponse": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "description": "Mean of factor averages that have ratings",
+                    "type": "number"
+                },
+                "branches": {
+                    "description": "Active branches (with params)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Organization"
+                    }
+                },
+                "brand": {
+                    "$ref": "#/definitions/model.Brand"
+                },
+                "factors": {
+                    "description": "Factor scores over all active branches: sum of ratings / number of ratings",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.FactorAggregate"
+                    }
+                }
+            }
+        },
+        "handler.BrandUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 5000
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 1
+                },
+                "website": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "handler.BrandsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.BrandWithCount"
+                    }
+                }
+            }
+        },
+        "handler.CreateUserParamsRequest": {
+            "type": "object",
+            "properties": {
+                "appearance": {
+                    "type": "boolean"
+                },
+                "calmness": {
+                    "type": "boolean"
+                },
+                "intuitiveness": {
+                    "type": "boolean"
+                },
+                "lighting": {
+                    "type": "boolean"
                 },
                 "people_density": {
                     "type": "boolean"
                 },
-                "self_service": {
-                    "type": "boolean"
+                "self_service": {
+                    "type": "boolean"
+                },
+                "signage": {
+                    "type": "boolean"
+                },
+                "smell": {
+                    "type": "boolean"
+                },
+                "staff_attitude": {
+                    "type": "boolean"
+                },
+                "tactility": {
+                    "type": "boolean"
+                },
+                "temperature": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.LeaderboardBoard": {
+            "type": "object",
+            "properties": {
+                "built_at": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.LeaderboardItem"
+                    }
+                },
+                "params": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "preset": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.LeaderboardItem": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "average": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "opening_hours": {
+                    "$ref": "#/definitions/model.OpeningHours"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "organization_type": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "quiet_hours": {
+                    "$ref": "#/definitions/model.OpeningHours"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "review_count": {
+                    "type": "integer"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.LeaderboardResponse": {
+            "type": "object",
+            "properties": {
+                "boards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.LeaderboardBoard"
+                    }
+                },
+                "organization_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.MyOrganizationsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Organization"
+                    }
+                }
+            }
+        },
+        "handler.NotificationsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Notification"
+                    }
+                }
+            }
+        },
+        "handler.OrganizationAddressCandidate": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "house_match": {
+                    "description": "номер дома совпадает с запрошенным",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_type": {
+                    "type": "string"
+                },
+                "similarity": {
+                    "description": "0..1, триграммное сходство нормализованных адресов",
+                    "type": "number"
+                }
+            }
+        },
+        "handler.OrganizationAmenitiesRequest": {
+            "type": "object",
+            "properties": {
+                "amenities": {
+                    "description": "Amenity slugs from GET /amenities; replaces the declared set (empty list clears it)",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handler.OrganizationAmenitiesResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.OrganizationAmenityItem"
+                    }
+                },
+                "organization_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.OrganizationAmenityItem": {
+            "type": "object",
+            "properties": {
+                "amenity": {
+                    "type": "string"
+                },
+                "confirmations": {
+                    "type": "integer"
+                },
+                "declared_by": {
+                    "type": "integer"
+                },
+                "disputed": {
+                    "description": "Disputed: оспаривают чаще, чем подтверждают — такое удобство не учитывается в фильтре поиска",
+                    "type": "boolean"
+                },
+                "disputes": {
+                    "type": "integer"
+                },
+                "my_vote": {
+                    "type": "boolean"
+                },
+                "name_en": {
+                    "type": "string"
+                },
+                "name_ru": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.OrganizationByAddressNotFoundResponse": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.OrganizationAddressCandidate"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.OrganizationByAddressRequest": {
+            "type": "object",
+            "required": [
+                "address"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.OrganizationByAddressResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "map_path": {
+                    "type": "string"
+                },
+                "match": {
+                    "description": "exact | normalized",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "opening_hours": {
+                    "$ref": "#/definitions/model.OpeningHours"
+                },
+                "organization_type": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "picture_path": {
+                    "type": "string"
+                },
+                "quiet_hours": {
+                    "$ref": "#/definitions/model.OpeningHours"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.OrganizationClaimCreateRequest": {
+            "type": "object",
+            "required": [
+                "evidence"
+            ],
+            "properties": {
+                "contact": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "evidence": {
+                    "description": "документы, ссылки, должность и т.п.",
+                    "type": "string",
+                    "maxLength": 5000
+                }
+            }
+        },
+        "handler.OrganizationClaimReviewRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "handler.OrganizationClaimsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OrganizationClaim"
+                    }
+                }
+            }
+        },
+        "handler.OrganizationCommentCreateRequest": {
+            "type": "object",
+            "required": [
+                "organization_id"
+            ],
+            "properties": {
+                "appearance_comment": {
+                    "type": "string"
+                },
+                "appearance_value": {
+                    "type": "integer"
+                },
+                "calmness_comment": {
+                    "type": "string"
+                },
+                "calmness_value": {
+                    "type": "integer"
+                },
+                "intuitiveness_comment": {
+                    "type": "string"
+                },
+                "intuitiveness_value": {
+                    "type": "integer"
+                },
+                "lighting_comment": {
+                    "type": "string"
+                },
+                "lighting_value": {
+                    "type": "integer"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "people_density_comment": {
+                    "type": "string"
+                },
+                "people_density_value": {
+                    "type": "integer"
+                },
+                "self_service_comment": {
+                    "type": "string"
+                },
+                "self_service_value": {
+                    "type": "integer"
+                },
+                "signage_comment": {
+                    "type": "string"
+                },
+                "signage_value": {
+                    "type": "integer"
+                },
+                "smell_comment": {
+                    "type": "string"
+                },
+                "smell_value": {
+                    "type": "integer"
+                },
+                "staff_attitude_comment": {
+                    "type": "string"
+                },
+                "staff_attitude_value": {
+                    "type": "integer"
+                },
+                "tactility_comment": {
+                    "type": "string"
+                },
+                "tactility_value": {
+                    "type": "integer"
+                },
+                "temperature_comment": {
+                    "type": "string"
+                },
+                "temperature_value": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.OrganizationCommentCreateResponse": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "$ref": "#/definitions/model.OrganizationComment"
+                },
+                "updated_aggregates": {
+                    "$ref": "#/definitions/model.OrganizationParams"
+                }
+            }
+        },
+        "handler.OrganizationCommentListItem": {
+            "type": "object",
+            "properties": {
+                "avg_val": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "response": {
+                    "description": "Ответ организации (владелец или admin), nil — ответа нет",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.OrganizationCommentResponse"
+                        }
+                    ]
+                },
+                "text": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "user_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.OrganizationCommentListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.OrganizationCommentListItem"
+                    }
+                },
+                "organization_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.OrganizationCommentResponseRequest": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "text": {
+                    "type": "string",
+                    "maxLength": 5000
+                }
+            }
+        },
+        "handler.OrganizationCompareItem": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "best_factor": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "factors": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/handler.OrganizationParamFactor"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "opening_hours": {
+                    "$ref": "#/definitions/model.OpeningHours"
+                },
+                "organization_type": {
+                    "type": "string"
+                },
+                "personal_breakdown": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ParamBreakdown"
+                    }
+                },
+                "personal_score": {
+                    "description": "null если нет токена или не выбраны параметры",
+                    "type": "number"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "quiet_hours": {
+                    "$ref": "#/definitions/model.OpeningHours"
+                },
+                "website": {
+                    "type": "string"
+                },
+                "worst_factor": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.OrganizationCompareResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.OrganizationCompareItem"
+                    }
+                },
+                "personal_params": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handler.OrganizationCreateRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "organization_type"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "brand_id": {
+                    "description": "Branch of a brand owned by the caller; owners may create any number of branches of their brands",
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 5000
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "opening_hours": {
+                    "$ref": "#/definitions/model.OpeningHours"
+                },
+                "organization_type": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "quiet_hours": {
+                    "$ref": "#/definitions/model.OpeningHours"
+                },
+                "website": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "handler.OrganizationDashboardResponse": {
+            "type": "object",
+            "properties": {
+                "factor_words": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/service.WordCount"
+                        }
+                    }
+                },
+                "factors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.FactorComparison"
+                    }
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "period": {
+                    "type": "string"
+                },
+                "recent": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.OrganizationCommentListItem"
+                    }
+                },
+                "total_reviews": {
+                    "type": "integer"
+                },
+                "undated_reviews": {
+                    "description": "отзывы без даты (оставлены до её появления): входят в total_reviews, но не в volume",
+                    "type": "integer"
+                },
+                "volume": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.VolumePoint"
+                    }
+                },
+                "weakest": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.FactorComparison"
+                    }
+                },
+                "words": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.WordCount"
+                    }
+                }
+            }
+        },
+        "handler.OrganizationHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OrganizationRevision"
+                    }
+                },
+                "organization_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.OrganizationParamFactor": {
+            "type": "object",
+            "properties": {
+                "avg": {
+                    "type": "number"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "percentile": {
+                    "description": "среди организаций того же типа",
+                    "type": "number"
+                }
+            }
+        },
+        "handler.OrganizationParamsAverageRequest": {
+            "type": "object",
+            "required": [
+                "organization_id",
+                "params"
+            ],
+            "properties": {
+                "aggregate": {
+                    "description": "plain (default) | sensitivity — среднее, где оценки авторов, отметивших параметр важным, весят больше",
+                    "type": "string",
+                    "enum": [
+                        "plain",
+                        "sensitivity"
+                    ]
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "params": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handler.OrganizationParamsAverageResponse": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "number"
+                },
+                "breakdown": {
+                    "description": "Breakdown по каждому запрошенному параметру: avg, count, вес, учтён ли и почему нет",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ParamBreakdown"
+                    }
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "params": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handler.OrganizationParamsBatchItem": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "number"
+                },
+                "breakdown": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ParamBreakdown"
+                    }
+                },
+                "factors": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/handler.OrganizationParamFactor"
+                    }
+                },
+                "organization_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.OrganizationParamsBatchRequest": {
+            "type": "object",
+            "required": [
+                "organization_ids"
+            ],
+            "properties": {
+                "aggregate": {
+                    "description": "plain (default) | sensitivity — среднее, где оценки авторов, отметивших параметр важным, весят больше",
+                    "type": "string",
+                    "enum": [
+                        "plain",
+                        "sensitivity"
+                    ]
+                },
+                "organization_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "params": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "weights": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                }
+            }
+        },
+        "handler.OrganizationParamsBatchResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.OrganizationParamsBatchItem"
+                    }
+                },
+                "not_found": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "params": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "weights": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                }
+            }
+        },
+        "handler.OrganizationParamsWithOrgRequest": {
+            "type": "object",
+            "required": [
+                "organization_id",
+                "params"
+            ],
+            "properties": {
+                "aggregate": {
+                    "description": "plain (default) | sensitivity — среднее, где оценки авторов, отметивших параметр важным, весят больше",
+                    "type": "string",
+                    "enum": [
+                        "plain",
+                        "sensitivity"
+                    ]
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "params": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handler.OrganizationParamsWithOrgResponse": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "number"
+                },
+                "breakdown": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ParamBreakdown"
+                    }
+                },
+                "organization": {
+                    "type": "object",
+                    "properties": {
+                        "address": {
+                            "type": "string"
+                        },
+                        "description": {
+                            "type": "string"
+                        },
+                        "id": {
+                            "type": "integer"
+                        },
+                        "latitude": {
+                            "type": "number"
+                        },
+                        "longitude": {
+                            "type": "number"
+                        },
+                        "map_path": {
+                            "type": "string"
+                        },
+                        "name": {
+                            "type": "string"
+                        },
+                        "opening_hours": {
+                            "$ref": "#/definitions/model.OpeningHours"
+                        },
+                        "organization_type": {
+                            "type": "string"
+                        },
+                        "percentiles": {
+                            "description": "перцентили (0..100) по всем оценённым параметрам среди организаций того же типа",
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "number",
+                                "format": "float64"
+                            }
+                        },
+                        "phone": {
+                            "type": "string"
+                        },
+                        "picture_path": {
+                            "type": "string"
+                        },
+                        "quiet_hours": {
+                            "$ref": "#/definitions/model.OpeningHours"
+                        },
+                        "website": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "params": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handler.OrganizationSearchItem": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "description": "среднее по params, если они заданы",
+                    "type": "number"
+                },
+                "organization": {
+                    "$ref": "#/definitions/model.Organization"
+                },
+                "rank": {
+                    "type": "number"
+                }
+            }
+        },
+        "handler.OrganizationSearchResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.OrganizationSearchItem"
+                    }
+                },
+                "query": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.OrganizationStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "description": "active | temporarily_closed | closed_permanently",
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "temporarily_closed",
+                        "closed_permanently"
+                    ]
+                }
+            }
+        },
+        "handler.OrganizationTypesResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.OrganizationTypeCount"
+                    }
+                }
+            }
+        },
+        "handler.OrganizationUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 5000
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "opening_hours": {
+                    "$ref": "#/definitions/model.OpeningHours"
+                },
+                "organization_type": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "quiet_hours": {
+                    "$ref": "#/definitions/model.OpeningHours"
+                },
+                "status": {
+                    "description": "active | temporarily_closed | closed_permanently",
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "temporarily_closed",
+                        "closed_permanently"
+                    ]
+                },
+                "website": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "handler.OrganizationWithSelectedAverage": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "number"
+                },
+                "breakdown": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ParamBreakdown"
+                    }
+                },
+                "organization": {},
+                "params": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "review_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.OrganizationsParamsAverageByTypeRequest": {
+            "type": "object",
+            "required": [
+                "organization_type",
+                "params"
+            ],
+            "properties": {
+                "aggregate": {
+                    "description": "plain (default) | sensitivity — среднее, где оценки авторов, отметивших параметр важным, весят больше",
+                    "type": "string",
+                    "enum": [
+                        "plain",
+                        "sensitivity"
+                    ]
+                },
+                "amenities": {
+                    "description": "Required amenity slugs (see GET /amenities); disputed declarations do not count",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "brand_id": {
+                    "description": "Only branches of this brand",
+                    "type": "integer"
+                },
+                "include_archived": {
+                    "description": "Include temporarily/permanently closed organizations (excluded by default)",
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer",
+                    "maximum": 500,
+                    "minimum": 1
+                },
+                "min_params": {
+                    "description": "Optional per-factor minimums (avg \u003e= value), e.g. {\"smell\": 4, \"lighting\": 3.5}",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                },
+                "min_reviews": {
+                    "description": "Optional minimum number of comments for organization",
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "open_at": {
+                    "type": "string"
+                },
+                "open_now": {
+                    "description": "Only organizations open now (or at open_at, RFC3339) according to their opening hours",
+                    "type": "boolean"
+                },
+                "organization_type": {
+                    "type": "string"
+                },
+                "params": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "quiet_at": {
+                    "type": "string"
+                },
+                "quiet_now": {
+                    "description": "Only organizations in quiet hours now (or at quiet_at, RFC3339)",
+                    "type": "boolean"
+                },
+                "sort": {
+                    "description": "average_desc (default) | average_asc | reviews_desc",
+                    "type": "string",
+                    "enum": [
+                        "average_desc",
+                        "average_asc",
+                        "reviews_desc"
+                    ]
+                },
+                "threshold": {
+                    "description": "Optional threshold; if omitted, defaults to 3.0",
+                    "type": "number"
+                }
+            }
+        },
+        "handler.OrganizationsParamsAverageByTypeResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.OrganizationWithSelectedAverage"
+                    }
+                },
+                "organization_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.RecommendationItem": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
                 },
-                "signage": {
-                    "type": "boolean"
+                "latitude": {
+                    "type": "number"
                 },
-                "smell": {
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "opening_hours": {
+                    "$ref": "#/definitions/model.OpeningHours"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "organization_type": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "quiet_hours": {
+                    "$ref": "#/definitions/model.OpeningHours"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "supporters": {
+                    "type": "integer"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.RecommendationsResponse": {
+            "type": "object",
+            "properties": {
+                "built_at": {
+                    "description": "null — для пользователя ещё нет рекомендаций",
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.RecommendationItem"
+                    }
+                }
+            }
+        },
+        "handler.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.RegisterResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/model.User"
+                }
+            }
+        },
+        "handler.SimilarOrganizationItem": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "distance": {
+                    "description": "евклидово расстояние между нормализованными векторами",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "opening_hours": {
+                    "$ref": "#/definitions/model.OpeningHours"
+                },
+                "organization_type": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "quiet_hours": {
+                    "$ref": "#/definitions/model.OpeningHours"
+                },
+                "similarity": {
+                    "description": "0..1, больше — ближе",
+                    "type": "number"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.SimilarOrganizationsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.SimilarOrganizationItem"
+                    }
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Amenity": {
+            "type": "object",
+            "properties": {
+                "name_en": {
+                    "type": "string"
+                },
+                "name_ru": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Brand": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "integer"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Notification": {
+            "type": "object",
+            "properties": {
+                "claim_id": {
+                    "type": "integer"
+                },
+                "comment_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.OpeningException": {
+            "type": "object",
+            "properties": {
+                "closed": {
                     "type": "boolean"
                 },
-                "staff_attitude": {
-                    "type": "boolean"
+                "date": {
+                    "type": "string"
+                },
+                "intervals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TimeInterval"
+                    }
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.OpeningHours": {
+            "type": "object",
+            "properties": {
+                "exceptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OpeningException"
+                    }
+                },
+                "timezone": {
+                    "description": "IANA, по умолчанию DefaultTimezone",
+                    "type": "string"
+                },
+                "weekly": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.WeeklyOpening"
+                    }
+                }
+            }
+        },
+        "model.Organization": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "brand_id": {
+                    "description": "сеть, к которой относится филиал",
+                    "type": "integer"
+                },
+                "deleted_at": {
+                    "description": "мягкое удаление: отзывы и агрегаты сохраняются",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "description": "optional",
+                    "type": "number"
+                },
+                "longitude": {
+                    "description": "optional",
+                    "type": "number"
+                },
+                "map_path": {
+                    "description": "относительный путь к карте (изображение)",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "opening_hours": {
+                    "description": "недельное расписание с исключениями",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.OpeningHours"
+                        }
+                    ]
+                },
+                "organization_type": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "description": "ВНИМАНИЕ: для обычного владельца (role=owner) разрешаем только одну организацию логикой приложения; admin может иметь несколько",
+                    "type": "integer"
+                },
+                "params": {
+                    "$ref": "#/definitions/model.OrganizationParams"
+                },
+                "percentiles": {
+                    "description": "Percentiles: param -\u003e перцентиль (0..100) среди организаций того же типа; заполняется обработчиками",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "picture_path": {
+                    "description": "относительный путь к общей картинке",
+                    "type": "string"
+                },
+                "quiet_hours": {
+                    "description": "«тихие часы» (приглушённый свет, без музыки) в том же формате",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.OpeningHours"
+                        }
+                    ]
                 },
-                "tactility": {
-                    "type": "boolean"
+                "status": {
+                    "description": "см. OrganizationStatus*",
+                    "type": "string"
                 },
-                "temperature": {
-                    "type": "boolean"
+                "website": {
+                    "type": "string"
                 }
             }
         },
-        "handler.LoginRequest": {
+        "model.OrganizationClaim": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "claimant_id": {
+                    "type": "integer"
+                },
+                "contact": {
                     "type": "string"
                 },
-                "password": {
+                "created_at": {
                     "type": "string"
-                }
-            }
-        },
-        "handler.LoginResponse": {
-            "type": "object",
-            "properties": {
-                "token": {
+                },
+                "evidence": {
+                    "description": "чем заявитель подтверждает право на организацию",
                     "type": "string"
-                }
-            }
-        },
-        "handler.OrganizationByAddressRequest": {
-            "type": "object",
-            "required": [
-                "address"
-            ],
-            "properties": {
-                "address": {
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OrganizationClaimEvent"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "previous_owner_id": {
+                    "description": "владелец до одобрения",
+                    "type": "integer"
+                },
+                "review_note": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewer_id": {
+                    "type": "integer"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "handler.OrganizationByAddressResponse": {
+        "model.OrganizationClaimEvent": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "id": {
+                "actor_id": {
                     "type": "integer"
                 },
-                "latitude": {
-                    "type": "number"
-                },
-                "longitude": {
-                    "type": "number"
+                "claim_id": {
+                    "type": "integer"
                 },
-                "map_path": {
+                "created_at": {
                     "type": "string"
                 },
-                "organization_type": {
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
                     "type": "string"
                 },
-                "picture_path": {
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "handler.OrganizationCommentCreateRequest": {
+        "model.OrganizationComment": {
             "type": "object",
-            "required": [
-                "organization_id"
-            ],
             "properties": {
                 "appearance_comment": {
                     "type": "string"
@@ -1283,12 +5822,23 @@ const docTemplate = `{
                 "appearance_value": {
                     "type": "integer"
                 },
+                "avg_val": {
+                    "description": "средняя по непустым параметрам (вычисляется при создании)",
+                    "type": "number"
+                },
                 "calmness_comment": {
                     "type": "string"
                 },
                 "calmness_value": {
                     "type": "integer"
                 },
+                "created_at": {
+                    "description": "CreatedAt: nil у отзывов, оставленных до появления поля",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
                 "intuitiveness_comment": {
                     "type": "string"
                 },
@@ -1310,6 +5860,14 @@ const docTemplate = `{
                 "people_density_value": {
                     "type": "integer"
                 },
+                "response": {
+                    "description": "ответ организации",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.OrganizationCommentResponse"
+                        }
+                    ]
+                },
                 "self_service_comment": {
                     "type": "string"
                 },
@@ -1347,175 +5905,310 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "text": {
+                    "description": "общий текст комментария (опционально)",
                     "type": "string"
+                },
+                "user_id": {
+                    "description": "author of the comment",
+                    "type": "integer"
                 }
             }
         },
-        "handler.OrganizationCommentCreateResponse": {
+        "model.OrganizationCommentResponse": {
             "type": "object",
             "properties": {
-                "comment": {
-                    "$ref": "#/definitions/model.OrganizationComment"
+                "author_id": {
+                    "description": "кто ответил: владелец организации или admin",
+                    "type": "integer"
                 },
-                "updated_aggregates": {
-                    "$ref": "#/definitions/model.OrganizationParams"
+                "comment_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "handler.OrganizationCommentListItem": {
+        "model.OrganizationParams": {
             "type": "object",
             "properties": {
-                "avg_val": {
+                "appearance_avg": {
+                    "type": "number"
+                },
+                "appearance_count": {
+                    "type": "integer"
+                },
+                "appearance_sensitive_avg": {
+                    "type": "number"
+                },
+                "appearance_sum": {
+                    "type": "integer"
+                },
+                "appearance_weight_sum": {
+                    "type": "number"
+                },
+                "appearance_weighted_sum": {
+                    "type": "number"
+                },
+                "calmness_avg": {
+                    "type": "number"
+                },
+                "calmness_count": {
+                    "type": "integer"
+                },
+                "calmness_sensitive_avg": {
+                    "type": "number"
+                },
+                "calmness_sum": {
+                    "type": "integer"
+                },
+                "calmness_weight_sum": {
+                    "type": "number"
+                },
+                "calmness_weighted_sum": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "intuitiveness_avg": {
+                    "type": "number"
+                },
+                "intuitiveness_count": {
+                    "type": "integer"
+                },
+                "intuitiveness_sensitive_avg": {
+                    "type": "number"
+                },
+                "intuitiveness_sum": {
+                    "type": "integer"
+                },
+                "intuitiveness_weight_sum": {
+                    "type": "number"
+                },
+                "intuitiveness_weighted_sum": {
+                    "type": "number"
+                },
+                "lighting_avg": {
+                    "type": "number"
+                },
+                "lighting_count": {
+                    "type": "integer"
+                },
+                "lighting_sensitive_avg": {
+                    "type": "number"
+                },
+                "lighting_sum": {
+                    "type": "integer"
+                },
+                "lighting_weight_sum": {
+                    "type": "number"
+                },
+                "lighting_weighted_sum": {
+                    "type": "number"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "people_density_avg": {
+                    "type": "number"
+                },
+                "people_density_count": {
+                    "type": "integer"
+                },
+                "people_density_sensitive_avg": {
+                    "type": "number"
+                },
+                "people_density_sum": {
+                    "type": "integer"
+                },
+                "people_density_weight_sum": {
+                    "type": "number"
+                },
+                "people_density_weighted_sum": {
+                    "type": "number"
+                },
+                "self_service_avg": {
+                    "type": "number"
+                },
+                "self_service_count": {
+                    "type": "integer"
+                },
+                "self_service_sensitive_avg": {
+                    "type": "number"
+                },
+                "self_service_sum": {
+                    "type": "integer"
+                },
+                "self_service_weight_sum": {
+                    "type": "number"
+                },
+                "self_service_weighted_sum": {
+                    "type": "number"
+                },
+                "signage_avg": {
+                    "type": "number"
+                },
+                "signage_count": {
+                    "type": "integer"
+                },
+                "signage_sensitive_avg": {
+                    "type": "number"
+                },
+                "signage_sum": {
+                    "type": "integer"
+                },
+                "signage_weight_sum": {
+                    "type": "number"
+                },
+                "signage_weighted_sum": {
+                    "type": "number"
+                },
+                "smell_avg": {
+                    "type": "number"
+                },
+                "smell_count": {
+                    "type": "integer"
+                },
+                "smell_sensitive_avg": {
+                    "type": "number"
+                },
+                "smell_sum": {
+                    "type": "integer"
+                },
+                "smell_weight_sum": {
+                    "type": "number"
+                },
+                "smell_weighted_sum": {
+                    "type": "number"
+                },
+                "staff_attitude_avg": {
+                    "type": "number"
+                },
+                "staff_attitude_count": {
+                    "type": "integer"
+                },
+                "staff_attitude_sensitive_avg": {
+                    "type": "number"
+                },
+                "staff_attitude_sum": {
+                    "type": "integer"
+                },
+                "staff_attitude_weight_sum": {
                     "type": "number"
                 },
-                "id": {
+                "staff_attitude_weighted_sum": {
+                    "type": "number"
+                },
+                "tactility_avg": {
+                    "type": "number"
+                },
+                "tactility_count": {
                     "type": "integer"
                 },
-                "text": {
-                    "type": "string"
+                "tactility_sensitive_avg": {
+                    "type": "number"
                 },
-                "user_id": {
+                "tactility_sum": {
                     "type": "integer"
                 },
-                "user_name": {
-                    "type": "string"
-                }
-            }
-        },
-        "handler.OrganizationCommentListResponse": {
-            "type": "object",
-            "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handler.OrganizationCommentListItem"
-                    }
+                "tactility_weight_sum": {
+                    "type": "number"
                 },
-                "organization_id": {
+                "tactility_weighted_sum": {
+                    "type": "number"
+                },
+                "temperature_avg": {
+                    "type": "number"
+                },
+                "temperature_count": {
                     "type": "integer"
-                }
-            }
-        },
-        "handler.OrganizationCreateRequest": {
-            "type": "object",
-            "required": [
-                "address",
-                "organization_type"
-            ],
-            "properties": {
-                "address": {
-                    "type": "string"
                 },
-                "latitude": {
+                "temperature_sensitive_avg": {
                     "type": "number"
                 },
-                "longitude": {
+                "temperature_sum": {
+                    "type": "integer"
+                },
+                "temperature_weight_sum": {
                     "type": "number"
                 },
-                "organization_type": {
-                    "type": "string"
+                "temperature_weighted_sum": {
+                    "type": "number"
                 }
             }
         },
-        "handler.OrganizationParamsAverageRequest": {
+        "model.OrganizationRevision": {
             "type": "object",
-            "required": [
-                "organization_id",
-                "params"
-            ],
             "properties": {
-                "organization_id": {
+                "action": {
+                    "type": "string"
+                },
+                "actor_id": {
                     "type": "integer"
                 },
-                "params": {
+                "after": {
+                    "$ref": "#/definitions/model.OrganizationSnapshot"
+                },
+                "before": {
+                    "description": "nil для create",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.OrganizationSnapshot"
+                        }
+                    ]
+                },
+                "changed": {
+                    "description": "Changed: имена полей снимка, отличающихся в Before и After; заполняется сервисом",
                     "type": "array",
-                    "minItems": 1,
                     "items": {
                         "type": "string"
                     }
-                }
-            }
-        },
-        "handler.OrganizationParamsAverageResponse": {
-            "type": "object",
-            "properties": {
-                "average": {
-                    "type": "number"
                 },
-                "organization_id": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "params": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                }
-            }
-        },
-        "handler.OrganizationParamsWithOrgRequest": {
-            "type": "object",
-            "required": [
-                "organization_id",
-                "params"
-            ],
-            "properties": {
                 "organization_id": {
                     "type": "integer"
                 },
-                "params": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "string"
-                    }
+                "reverted_to": {
+                    "description": "для revert: ревизия, состояние после которой восстановлено",
+                    "type": "integer"
                 }
             }
         },
-        "handler.OrganizationParamsWithOrgResponse": {
+        "model.OrganizationSnapshot": {
             "type": "object",
             "properties": {
-                "average": {
-                    "type": "number"
-                },
-                "organization": {
-                    "type": "object",
-                    "properties": {
-                        "address": {
-                            "type": "string"
-                        },
-                        "id": {
-                            "type": "integer"
-                        },
-                        "latitude": {
-                            "type": "number"
-                        },
-                        "longitude": {
-                            "type": "number"
-                        },
-                        "map_path": {
-                            "type": "string"
-                        },
-                        "organization_type": {
-                            "type": "string"
-                        },
-                        "picture_path": {
-                            "type": "string"
-                        }
-                    }
+                "address": {
+                    "type": "string"
                 },
-                "params": {
+                "amenities": {
+                    "description": "Amenities: объявленные удобства (slug); заполняется только в ревизиях action=amenities",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
-                }
-            }
-        },
-        "handler.OrganizationUpdateRequest": {
-            "type": "object",
-            "properties": {
-                "address": {
+                },
+                "brand_id": {
+                    "type": "integer"
+                },
+                "description": {
                     "type": "string"
                 },
                 "latitude": {
@@ -1524,393 +6217,338 @@ const docTemplate = `{
                 "longitude": {
                     "type": "number"
                 },
-                "organization_type": {
+                "map_path": {
                     "type": "string"
-                }
-            }
-        },
-        "handler.OrganizationWithSelectedAverage": {
-            "type": "object",
-            "properties": {
-                "average": {
-                    "type": "number"
                 },
-                "organization": {},
-                "params": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                }
-            }
-        },
-        "handler.OrganizationsParamsAverageByTypeRequest": {
-            "type": "object",
-            "required": [
-                "organization_type",
-                "params"
-            ],
-            "properties": {
-                "organization_type": {
+                "name": {
                     "type": "string"
                 },
-                "params": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "threshold": {
-                    "description": "Optional threshold; if omitted, defaults to 3.0",
-                    "type": "number"
-                }
-            }
-        },
-        "handler.OrganizationsParamsAverageByTypeResponse": {
-            "type": "object",
-            "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handler.OrganizationWithSelectedAverage"
-                    }
+                "opening_hours": {
+                    "$ref": "#/definitions/model.OpeningHours"
                 },
                 "organization_type": {
                     "type": "string"
-                }
-            }
-        },
-        "handler.RegisterRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "name",
-                "password",
-                "role"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "owner_id": {
+                    "type": "integer"
                 },
-                "password": {
+                "phone": {
                     "type": "string"
                 },
-                "role": {
+                "picture_path": {
                     "type": "string"
-                }
-            }
-        },
-        "handler.RegisterResponse": {
-            "type": "object",
-            "properties": {
-                "token": {
+                },
+                "quiet_hours": {
+                    "$ref": "#/definitions/model.OpeningHours"
+                },
+                "status": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/model.User"
+                "website": {
+                    "type": "string"
                 }
             }
         },
-        "model.Organization": {
+        "model.TimeInterval": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "latitude": {
-                    "description": "optional",
-                    "type": "number"
-                },
-                "longitude": {
-                    "description": "optional",
-                    "type": "number"
-                },
-                "map_path": {
-                    "description": "относительный путь к карте (изображение)",
-                    "type": "string"
-                },
-                "organization_type": {
+                "closes": {
                     "type": "string"
                 },
-                "owner_id": {
-                    "description": "ВНИМАНИЕ: для обычного владельца (role=owner) разрешаем только одну организацию логикой приложения; admin может иметь несколько",
-                    "type": "integer"
-                },
-                "params": {
-                    "$ref": "#/definitions/model.OrganizationParams"
-                },
-                "picture_path": {
-                    "description": "относительный путь к общей картинке",
+                "opens": {
                     "type": "string"
                 }
             }
         },
-        "model.OrganizationComment": {
+        "model.User": {
             "type": "object",
             "properties": {
-                "appearance_comment": {
+                "email": {
                     "type": "string"
                 },
-                "appearance_value": {
+                "id": {
                     "type": "integer"
                 },
-                "avg_val": {
-                    "description": "средняя по непустым параметрам (вычисляется при создании)",
-                    "type": "number"
+                "name": {
+                    "type": "string"
                 },
-                "calmness_comment": {
+                "role": {
+                    "description": "например, \"user\", \"admin\"",
                     "type": "string"
+                }
+            }
+        },
+        "model.UserParams": {
+            "type": "object",
+            "properties": {
+                "appearance": {
+                    "type": "boolean"
                 },
-                "calmness_value": {
-                    "type": "integer"
+                "calmness": {
+                    "type": "boolean"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "intuitiveness_comment": {
-                    "type": "string"
+                "intuitiveness": {
+                    "type": "boolean"
                 },
-                "intuitiveness_value": {
-                    "type": "integer"
+                "lighting": {
+                    "type": "boolean"
                 },
-                "lighting_comment": {
-                    "type": "string"
+                "people_density": {
+                    "type": "boolean"
                 },
-                "lighting_value": {
-                    "type": "integer"
+                "self_service": {
+                    "type": "boolean"
                 },
-                "organization_id": {
-                    "type": "integer"
+                "signage": {
+                    "type": "boolean"
                 },
-                "people_density_comment": {
-                    "type": "string"
+                "smell": {
+                    "type": "boolean"
                 },
-                "people_density_value": {
-                    "type": "integer"
+                "staff_attitude": {
+                    "type": "boolean"
                 },
-                "self_service_comment": {
-                    "type": "string"
+                "tactility": {
+                    "type": "boolean"
                 },
-                "self_service_value": {
-                    "type": "integer"
+                "temperature": {
+                    "type": "boolean"
                 },
-                "signage_comment": {
+                "user_id": {
+                    "description": "Один к одному",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.WeeklyOpening": {
+            "type": "object",
+            "properties": {
+                "closes": {
                     "type": "string"
                 },
-                "signage_value": {
+                "day": {
                     "type": "integer"
                 },
-                "smell_comment": {
+                "opens": {
                     "type": "string"
-                },
-                "smell_value": {
+                }
+            }
+        },
+        "repository.BrandWithCount": {
+            "type": "object",
+            "properties": {
+                "branch_count": {
                     "type": "integer"
                 },
-                "staff_attitude_comment": {
+                "created_at": {
                     "type": "string"
                 },
-                "staff_attitude_value": {
-                    "type": "integer"
-                },
-                "tactility_comment": {
+                "description": {
                     "type": "string"
                 },
-                "tactility_value": {
+                "id": {
                     "type": "integer"
                 },
-                "temperature_comment": {
+                "name": {
                     "type": "string"
                 },
-                "temperature_value": {
+                "owner_id": {
                     "type": "integer"
                 },
-                "text": {
-                    "description": "общий текст комментария (опционально)",
+                "website": {
                     "type": "string"
-                },
-                "user_id": {
-                    "description": "author of the comment",
-                    "type": "integer"
                 }
             }
         },
-        "model.OrganizationParams": {
+        "repository.FactorAggregate": {
             "type": "object",
             "properties": {
-                "appearance_avg": {
+                "average": {
                     "type": "number"
                 },
-                "appearance_count": {
+                "count": {
                     "type": "integer"
                 },
-                "appearance_sum": {
-                    "type": "integer"
+                "param": {
+                    "type": "string"
                 },
-                "calmness_avg": {
+                "sensitive_average": {
                     "type": "number"
+                }
+            }
+        },
+        "repository.OrganizationTypeCount": {
+            "type": "object",
+            "properties": {
+                "name_en": {
+                    "type": "string"
                 },
-                "calmness_count": {
-                    "type": "integer"
+                "name_ru": {
+                    "type": "string"
                 },
-                "calmness_sum": {
+                "organization_count": {
                     "type": "integer"
                 },
-                "id": {
-                    "type": "integer"
+                "parent_slug": {
+                    "description": "категория верхнего уровня, nil — сама категория",
+                    "type": "string"
                 },
-                "intuitiveness_avg": {
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.FactorComparison": {
+            "type": "object",
+            "properties": {
+                "average": {
                     "type": "number"
                 },
-                "intuitiveness_count": {
-                    "type": "integer"
-                },
-                "intuitiveness_sum": {
+                "count": {
                     "type": "integer"
                 },
-                "lighting_avg": {
+                "delta": {
+                    "description": "Delta = average - type_average; 0 если у организации или типа нет оценок",
                     "type": "number"
                 },
-                "lighting_count": {
-                    "type": "integer"
+                "param": {
+                    "type": "string"
                 },
-                "lighting_sum": {
-                    "type": "integer"
+                "type_average": {
+                    "type": "number"
                 },
-                "organization_id": {
+                "type_count": {
                     "type": "integer"
+                }
+            }
+        },
+        "service.ImportResult": {
+            "type": "object",
+            "properties": {
+                "aborted": {
+                    "description": "атомарный импорт не выполнен из-за невалидных строк",
+                    "type": "boolean"
                 },
-                "people_density_avg": {
-                    "type": "number"
+                "atomic": {
+                    "type": "boolean"
                 },
-                "people_density_count": {
+                "created": {
                     "type": "integer"
                 },
-                "people_density_sum": {
-                    "type": "integer"
+                "dry_run": {
+                    "type": "boolean"
                 },
-                "self_service_avg": {
-                    "type": "number"
+                "duplicates": {
+                    "type": "integer"
                 },
-                "self_service_count": {
+                "failed": {
                     "type": "integer"
                 },
-                "self_service_sum": {
+                "invalid": {
                     "type": "integer"
                 },
-                "signage_avg": {
-                    "type": "number"
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ImportRowResult"
+                    }
                 },
-                "signage_count": {
+                "total": {
                     "type": "integer"
                 },
-                "signage_sum": {
+                "valid": {
                     "type": "integer"
+                }
+            }
+        },
+        "service.ImportRowResult": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
                 },
-                "smell_avg": {
-                    "type": "number"
-                },
-                "smell_count": {
+                "duplicate_of": {
+                    "description": "существующая организация",
                     "type": "integer"
                 },
-                "smell_sum": {
+                "duplicate_of_row": {
+                    "description": "более ранняя строка того же файла",
                     "type": "integer"
                 },
-                "staff_attitude_avg": {
-                    "type": "number"
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "staff_attitude_count": {
+                "name": {
+                    "type": "string"
+                },
+                "organization_id": {
                     "type": "integer"
                 },
-                "staff_attitude_sum": {
+                "row": {
                     "type": "integer"
                 },
-                "tactility_avg": {
+                "status": {
+                    "description": "created | valid | duplicate | invalid | failed",
+                    "type": "string"
+                }
+            }
+        },
+        "service.ParamBreakdown": {
+            "type": "object",
+            "properties": {
+                "avg": {
                     "type": "number"
                 },
-                "tactility_count": {
+                "count": {
                     "type": "integer"
                 },
-                "tactility_sum": {
-                    "type": "integer"
+                "included": {
+                    "type": "boolean"
                 },
-                "temperature_avg": {
+                "param": {
+                    "type": "string"
+                },
+                "percentile": {
+                    "description": "Percentile среди организаций того же типа (0..100); nil если не рассчитан",
                     "type": "number"
                 },
-                "temperature_count": {
-                    "type": "integer"
+                "reason": {
+                    "description": "not_rated | zero_weight (only when excluded)",
+                    "type": "string"
                 },
-                "temperature_sum": {
-                    "type": "integer"
+                "weight": {
+                    "type": "number"
                 }
             }
         },
-        "model.User": {
+        "service.VolumePoint": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
+                "average": {
+                    "type": "number"
                 },
-                "name": {
+                "period_start": {
                     "type": "string"
                 },
-                "role": {
-                    "description": "например, \"user\", \"admin\"",
-                    "type": "string"
+                "reviews": {
+                    "type": "integer"
                 }
             }
         },
-        "model.UserParams": {
+        "service.WordCount": {
             "type": "object",
             "properties": {
-                "appearance": {
-                    "type": "boolean"
-                },
-                "calmness": {
-                    "type": "boolean"
-                },
-                "id": {
+                "count": {
                     "type": "integer"
                 },
-                "intuitiveness": {
-                    "type": "boolean"
-                },
-                "lighting": {
-                    "type": "boolean"
-                },
-                "people_density": {
-                    "type": "boolean"
-                },
-                "self_service": {
-                    "type": "boolean"
-                },
-                "signage": {
-                    "type": "boolean"
-                },
-                "smell": {
-                    "type": "boolean"
-                },
-                "staff_attitude": {
-                    "type": "boolean"
-                },
-                "tactility": {
-                    "type": "boolean"
-                },
-                "temperature": {
-                    "type": "boolean"
-                },
-                "user_id": {
-                    "description": "Один к одному",
-                    "type": "integer"
+                "word": {
+                    "type": "string"
                 }
             }
         }
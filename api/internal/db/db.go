@@ -4,6 +4,7 @@ import (
 	"2gis-calm-map/api/config"
 	"2gis-calm-map/api/internal/model"
 	"log"
+	"strings"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -12,7 +13,12 @@ import (
 var DB *gorm.DB
 
 func Init(cfg *config.Config) {
-	database, err := gorm.Open(postgres.Open(cfg.GetDSN()), &gorm.Config{})
+	Open(cfg.GetDSN())
+}
+
+// Open connects to the database by DSN and applies migrations (also used by DB-backed tests and benchmarks).
+func Open(dsn string) {
+	database, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
 		log.Fatal("failed to connect database: ", err)
 	}
@@ -21,7 +27,7 @@ func Init(cfg *config.Config) {
 	log.Println("Database connected")
 
 	// MIGRATION: автоматически создаёт таблицы, если их нет
-	if err := DB.AutoMigrate(&model.User{}, &model.UserParams{}, &model.Organization{}, &model.OrganizationParams{}, &model.OrganizationComment{}); err != nil {
+	if err := DB.AutoMigrate(&model.User{}, &model.UserParams{}, &model.Organization{}, &model.OrganizationParams{}, &model.OrganizationComment{}, &model.OrganizationVector{}, &model.UserRecommendation{}, &model.OrganizationPercentile{}, &model.LeaderboardEntry{}, &model.OrganizationType{}, &model.OrganizationClaim{}, &model.OrganizationClaimEvent{}, &model.Notification{}, &model.OrganizationRevision{}, &model.Brand{}, &model.Amenity{}, &model.OrganizationAmenity{}, &model.AmenityVote{}, &model.OrganizationCommentResponse{}); err != nil {
 		log.Fatal("failed to migrate database: ", err)
 	}
 
@@ -39,5 +45,100 @@ func Init(cfg *config.Config) {
 		}
 	}
 
+	// Полнотекстовый поиск: вектор по названию, типу (slug и названия из справочника), адресу и описанию;
+	// russian — морфология, simple — точные слова. Колонка не описана в модели, поэтому AutoMigrate её не трогает,
+	// а заполняет её триггер (генерируемая колонка не может читать organization_types).
+	if err := setupOrganizationSearch(); err != nil {
+		log.Println("warn: failed to set up organization full-text search:", err)
+	}
+
+	// Нечёткий поиск по адресу: триграммы по нормализованному адресу
+	if err := DB.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm;").Error; err != nil {
+		log.Println("warn: failed to enable pg_trgm:", err)
+	} else if err := DB.Exec("CREATE INDEX IF NOT EXISTS idx_org_address_trgm ON organizations USING GIN (address_normalized gin_trgm_ops);").Error; err != nil {
+		log.Println("warn: failed to create index idx_org_address_trgm:", err)
+	}
+
+	// Похожие места: KNN-индекс (GiST по cube) над векторами организаций, иначе — полный перебор
+	if err := setupVectorIndex(); err != nil {
+		log.Println("warn: failed to set up vector index, similar search falls back to a full scan:", err)
+	} else {
+		VectorIndex = true
+	}
+
 	log.Println("Database connected, migrated, indexes adjusted")
 }
+
+// VectorIndex reports that organization_vectors has the cube columns and GiST indexes (see setupVectorIndex).
+var VectorIndex bool
+
+// setupVectorIndex adds generated cube columns to organization_vectors: embedding (components as is,
+// for euclidean distance) and direction (unit vector, NULL for zero vectors — euclidean order over unit
+// vectors is the cosine order), each with a GiST index serving ORDER BY col <-> point LIMIT k.
+// The columns are not in the model, so AutoMigrate leaves them alone; generated columns follow upserts.
+func setupVectorIndex() error {
+	if err := DB.Exec("CREATE EXTENSION IF NOT EXISTS cube;").Error; err != nil {
+		return err
+	}
+	cols := make([]string, len(model.ParamNames))
+	unit := make([]string, len(model.ParamNames))
+	for i, name := range model.ParamNames {
+		cols[i] = name
+		unit[i] = name + " / norm"
+	}
+	stmts := []string{
+		"ALTER TABLE organization_vectors ADD COLUMN IF NOT EXISTS embedding cube GENERATED ALWAYS AS (cube(ARRAY[" + strings.Join(cols, ", ") + "]::float8[])) STORED;",
+		"ALTER TABLE organization_vectors ADD COLUMN IF NOT EXISTS direction cube GENERATED ALWAYS AS (CASE WHEN norm > 0 THEN cube(ARRAY[" + strings.Join(unit, ", ") + "]::float8[]) END) STORED;",
+		"CREATE INDEX IF NOT EXISTS idx_org_vector_embedding ON organization_vectors USING GIST (embedding);",
+		"CREATE INDEX IF NOT EXISTS idx_org_vector_direction ON organization_vectors USING GIST (direction);",
+	}
+	for _, stmt := range stmts {
+		if err := DB.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setupOrganizationSearch() error {
+	hasColumn := DB.Migrator().HasColumn(&model.Organization{}, "search_vector")
+	if !hasColumn {
+		if err := DB.Exec("ALTER TABLE organizations ADD COLUMN IF NOT EXISTS search_vector tsvector;").Error; err != nil {
+			return err
+		}
+	}
+	if err := DB.Exec(`CREATE OR REPLACE FUNCTION organizations_search_vector_update() RETURNS trigger AS $$
+DECLARE
+	type_names text;
+BEGIN
+	SELECT coalesce(t.name_ru, '') || ' ' || coalesce(t.name_en, '') INTO type_names
+	FROM organization_types t WHERE t.slug = NEW.organization_type;
+	NEW.search_vector :=
+		setweight(to_tsvector('russian'::regconfig, coalesce(NEW.name, '')), 'A') ||
+		setweight(to_tsvector('simple'::regconfig, coalesce(NEW.name, '')), 'A') ||
+		setweight(to_tsvector('russian'::regconfig, replace(coalesce(NEW.organization_type, ''), '_', ' ') || ' ' || coalesce(type_names, '')), 'B') ||
+		setweight(to_tsvector('simple'::regconfig, replace(coalesce(NEW.organization_type, ''), '_', ' ') || ' ' || coalesce(type_names, '')), 'B') ||
+		setweight(to_tsvector('russian'::regconfig, coalesce(NEW.address, '')), 'C') ||
+		setweight(to_tsvector('simple'::regconfig, coalesce(NEW.address, '')), 'C') ||
+		setweight(to_tsvector('russian'::regconfig, coalesce(NEW.description, '')), 'D');
+	RETURN NEW;
+END
+$$ LANGUAGE plpgsql;`).Error; err != nil {
+		return err
+	}
+	if err := DB.Exec("DROP TRIGGER IF EXISTS trg_organizations_search_vector ON organizations;").Error; err != nil {
+		return err
+	}
+	if err := DB.Exec(`CREATE TRIGGER trg_organizations_search_vector BEFORE INSERT OR UPDATE ON organizations
+	FOR EACH ROW EXECUTE FUNCTION organizations_search_vector_update();`).Error; err != nil {
+		return err
+	}
+	if err := DB.Exec("CREATE INDEX IF NOT EXISTS idx_org_search ON organizations USING GIN (search_vector);").Error; err != nil {
+		return err
+	}
+	if !hasColumn {
+		// заполняем вектор для уже существующих строк (срабатывает триггер)
+		return DB.Exec("UPDATE organizations SET name = name;").Error
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var amenityService = service.NewAmenityService()
+
+type AmenitiesResponse struct {
+	Items []model.Amenity `json:"items"`
+}
+
+type OrganizationAmenitiesRequest struct {
+	// Amenity slugs from GET /amenities; replaces the declared set (empty list clears it)
+	Amenities []string `json:"amenities" binding:"max=50"`
+}
+
+type AmenityVoteRequest struct {
+	// true — подтверждаю, false — оспариваю
+	Confirmed *bool `json:"confirmed" binding:"required"`
+}
+
+type OrganizationAmenityItem struct {
+	Amenity       string `json:"amenity"`
+	NameRu        string `json:"name_ru"`
+	NameEn        string `json:"name_en"`
+	DeclaredBy    uint   `json:"declared_by"`
+	Confirmations uint   `json:"confirmations"`
+	Disputes      uint   `json:"disputes"`
+	// Disputed: оспаривают чаще, чем подтверждают — такое удобство не учитывается в фильтре поиска
+	Disputed bool  `json:"disputed"`
+	MyVote   *bool `json:"my_vote,omitempty"`
+}
+
+type OrganizationAmenitiesResponse struct {
+	OrganizationID uint                      `json:"organization_id"`
+	Items          []OrganizationAmenityItem `json:"items"`
+}
+
+func amenityItem(a model.OrganizationAmenity, myVote *bool) OrganizationAmenityItem {
+	return OrganizationAmenityItem{
+		Amenity:       a.AmenitySlug,
+		NameRu:        a.Amenity.NameRu,
+		NameEn:        a.Amenity.NameEn,
+		DeclaredBy:    a.DeclaredBy,
+		Confirmations: a.Confirmations,
+		Disputes:      a.Disputes,
+		Disputed:      a.Disputed(),
+		MyVote:        myVote,
+	}
+}
+
+func amenitiesResponse(orgID uint, views []service.OrganizationAmenityView) OrganizationAmenitiesResponse {
+	items := make([]OrganizationAmenityItem, 0, len(views))
+	for _, v := range views {
+		items = append(items, amenityItem(v.OrganizationAmenity, v.MyVote))
+	}
+	return OrganizationAmenitiesResponse{OrganizationID: orgID, Items: items}
+}
+
+// GetAmenities godoc
+// @Summary Amenities catalog
+// @Description Справочник сенсорных удобств (тихая комната, регулируемый свет, наушники и т.п.). slug используется при объявлении удобств и в фильтрах поиска (amenities). Публично.
+// @Tags amenities
+// @Produce json
+// @Success 200 {object} AmenitiesResponse
+// @Failure 500 {object} map[string]string
+// @Router /amenities [get]
+func GetAmenities(c *gin.Context) {
+	list, err := amenityService.Catalog()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, AmenitiesResponse{Items: list})
+}
+
+// GetOrganizationAmenities godoc
+// @Summary Organization amenities
+// @Description Удобства, объявленные владельцем, с числом подтверждений и опровержений от посетителей. С токеном — также собственный голос (my_vote). Публично.
+// @Tags amenities
+// @Produce json
+// @Param organization_id path int true "Organization ID"
+// @Success 200 {object} OrganizationAmenitiesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organization/{organization_id}/amenities [get]
+func GetOrganizationAmenities(c *gin.Context) {
+	orgID, err := strconv.ParseUint(c.Param("organization_id"), 10, 64)
+	if err != nil || orgID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization_id"})
+		return
+	}
+	if _, err := organizationService.GetByID(uint(orgID)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "organization not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	var uid uint
+	if v, ok := c.Get("user_id"); ok {
+		uid = v.(uint)
+	}
+	views, err := amenityService.ForOrganization(uint(orgID), uid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, amenitiesResponse(uint(orgID), views))
+}
+
+// PutOrganizationAmenities godoc
+// @Summary Declare organization amenities
+// @Description Заменяет набор объявленных удобств. Голоса по убранным удобствам удаляются, по оставшимся — сохраняются. Владелец организации (или её бренда) либо admin.
+// @Tags amenities
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path int true "Organization ID"
+// @Param input body OrganizationAmenitiesRequest true "Amenities"
+// @Success 200 {object} OrganizationAmenitiesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organization/{organization_id}/amenities [put]
+func PutOrganizationAmenities(c *gin.Context) {
+	org, uid, ok := managedOrganization(c)
+	if !ok {
+		return
+	}
+	var req OrganizationAmenitiesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	views, err := amenityService.Declare(org.ID, uid, req.Amenities)
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownAmenity) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error() + " (see GET /amenities)"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, amenitiesResponse(org.ID, views))
+}
+
+// VoteOrganizationAmenity godoc
+// @Summary Confirm or dispute an amenity
+// @Description Посетитель подтверждает (confirmed=true) или оспаривает (false) объявленное удобство; повторный голос заменяет предыдущий. Голосовать могут только пользователи, оставившие отзыв об организации (иначе 403); владелец организации голосовать не может.
+// @Tags amenities
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path int true "Organization ID"
+// @Param amenity path string true "Amenity slug"
+// @Param input body AmenityVoteRequest true "Vote"
+// @Success 200 {object} OrganizationAmenityItem
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organization/{organization_id}/amenities/{amenity}/vote [post]
+func VoteOrganizationAmenity(c *gin.Context) {
+	uidRaw, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	uid := uidRaw.(uint)
+	orgID, err := strconv.ParseUint(c.Param("organization_id"), 10, 64)
+	if err != nil || orgID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization_id"})
+		return
+	}
+	var req AmenityVoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	org, err := organizationService.GetByID(uint(orgID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "organization not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if org.OwnerID == uid {
+		c.JSON(http.StatusForbidden, gin.H{"error": "owners cannot vote on their own amenities"})
+		return
+	}
+	a, err := amenityService.Vote(org.ID, c.Param("amenity"), uid, *req.Confirmed)
+	if err != nil {
+		if errors.Is(err, service.ErrAmenityNotDeclared) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrAmenityVoteWithoutReview) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, amenityItem(a, req.Confirmed))
+}
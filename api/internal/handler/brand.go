@@ -0,0 +1,316 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/repository"
+	"2gis-calm-map/api/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var brandService = service.NewBrandService()
+
+type BrandCreateRequest struct {
+	Name        string  `json:"name" binding:"required,max=200"`
+	Description string  `json:"description" binding:"max=5000"`
+	Website     *string `json:"website" binding:"omitempty,url,max=500"`
+}
+
+type BrandUpdateRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=200"`
+	Description *string `json:"description" binding:"omitempty,max=5000"`
+	Website     *string `json:"website" binding:"omitempty,url,max=500"`
+}
+
+type BrandAttachRequest struct {
+	OrganizationID uint `json:"organization_id" binding:"required"`
+}
+
+type BrandsResponse struct {
+	Items []repository.BrandWithCount `json:"items"`
+}
+
+type BrandResponse struct {
+	Brand model.Brand `json:"brand"`
+	// Active branches (with params)
+	Branches []model.Organization `json:"branches"`
+	// Factor scores over all active branches: sum of ratings / number of ratings
+	Factors []repository.FactorAggregate `json:"factors"`
+	// Mean of factor averages that have ratings
+	Average float64 `json:"average"`
+}
+
+// managedBrand loads :brand_id and checks that the caller owns it or is an admin; on failure the response is written.
+func managedBrand(c *gin.Context) (model.Brand, uint, bool) {
+	uid, allowed := roleAllowed(c)
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return model.Brand{}, 0, false
+	}
+	brandID, err := strconv.ParseUint(c.Param("brand_id"), 10, 64)
+	if err != nil || brandID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid brand_id"})
+		return model.Brand{}, 0, false
+	}
+	brand, err := brandService.Get(uint(brandID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "brand not found"})
+			return brand, 0, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return brand, 0, false
+	}
+	if role, _ := c.Get("role"); role != "admin" && brand.OwnerID != uid {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return brand, 0, false
+	}
+	return brand, uid, true
+}
+
+// CreateBrand godoc
+// @Summary Create brand (chain)
+// @Description Бренд объединяет филиалы сети. Владелец бренда управляет всеми его организациями и может создавать новые филиалы (POST /organization с brand_id) без ограничения «одна организация на владельца». owner/admin.
+// @Tags brands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body BrandCreateRequest true "Brand"
+// @Success 200 {object} model.Brand
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /brands [post]
+func CreateBrand(c *gin.Context) {
+	uid, allowed := roleAllowed(c)
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	var req BrandCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	brand := model.Brand{Name: req.Name, Description: req.Description, Website: req.Website, OwnerID: uid}
+	if err := brandService.Create(&brand); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, brand)
+}
+
+// ListBrands godoc
+// @Summary List brands
+// @Description Бренды с числом активных филиалов. Публично.
+// @Tags brands
+// @Produce json
+// @Param owner_id query int false "Only brands of this owner"
+// @Param limit query int false "Max items (default 50, max 200)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} BrandsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /brands [get]
+func ListBrands(c *gin.Context) {
+	var ownerID uint64
+	if v := c.Query("owner_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid owner_id"})
+			return
+		}
+		ownerID = id
+	}
+	limit, offset := 50, 0
+	if v := c.Query("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l < 1 || l > 200 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be 1..200"})
+			return
+		}
+		limit = l
+	}
+	if v := c.Query("offset"); v != "" {
+		o, err := strconv.Atoi(v)
+		if err != nil || o < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid offset"})
+			return
+		}
+		offset = o
+	}
+	list, err := brandService.List(uint(ownerID), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, BrandsResponse{Items: list})
+}
+
+// GetBrand godoc
+// @Summary Brand with branches and aggregated scores
+// @Description Бренд, его активные филиалы и оценки по факторам, агрегированные по всем филиалам (сумма оценок / число оценок, а также взвешенное по чувствительности среднее). Публично.
+// @Tags brands
+// @Produce json
+// @Param brand_id path int true "Brand ID"
+// @Success 200 {object} BrandResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /brands/{brand_id} [get]
+func GetBrand(c *gin.Context) {
+	brandID, err := strconv.ParseUint(c.Param("brand_id"), 10, 64)
+	if err != nil || brandID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid brand_id"})
+		return
+	}
+	d, err := brandService.Detail(uint(brandID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "brand not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, BrandResponse{Brand: d.Brand, Branches: d.Branches, Factors: d.Factors, Average: d.Average})
+}
+
+// PatchBrand godoc
+// @Summary Update brand
+// @Description Владелец бренда или admin.
+// @Tags brands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param brand_id path int true "Brand ID"
+// @Param input body BrandUpdateRequest true "Fields to update"
+// @Success 200 {object} model.Brand
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /brands/{brand_id} [patch]
+func PatchBrand(c *gin.Context) {
+	brand, _, ok := managedBrand(c)
+	if !ok {
+		return
+	}
+	var req BrandUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	updates := map[string]interface{}{}
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.Website != nil {
+		updates["website"] = *req.Website
+	}
+	if len(updates) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no fields"})
+		return
+	}
+	brand, err := brandService.Update(brand.ID, updates)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, brand)
+}
+
+// AttachBrandOrganization godoc
+// @Summary Add organization to brand
+// @Description Делает организацию филиалом бренда. Нужны права на бренд (владелец или admin) и на организацию (её владелец или admin).
+// @Tags brands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param brand_id path int true "Brand ID"
+// @Param input body BrandAttachRequest true "Organization"
+// @Success 200 {object} model.Organization
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /brands/{brand_id}/organizations [post]
+func AttachBrandOrganization(c *gin.Context) {
+	brand, uid, ok := managedBrand(c)
+	if !ok {
+		return
+	}
+	var req BrandAttachRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	org, err := organizationService.GetByID(req.OrganizationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "organization not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if role, _ := c.Get("role"); role != "admin" && org.OwnerID != uid {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	org, err = brandService.Attach(brand.ID, org.ID, uid)
+	if err != nil {
+		if errors.Is(err, service.ErrOrganizationInOtherBrand) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, org)
+}
+
+// DetachBrandOrganization godoc
+// @Summary Remove organization from brand
+// @Description Владелец бренда, владелец организации или admin.
+// @Tags brands
+// @Produce json
+// @Security BearerAuth
+// @Param brand_id path int true "Brand ID"
+// @Param organization_id path int true "Organization ID"
+// @Success 200 {object} model.Organization
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /brands/{brand_id}/organizations/{organization_id} [delete]
+func DetachBrandOrganization(c *gin.Context) {
+	org, uid, ok := managedOrganization(c)
+	if !ok {
+		return
+	}
+	brandID, err := strconv.ParseUint(c.Param("brand_id"), 10, 64)
+	if err != nil || brandID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid brand_id"})
+		return
+	}
+	if org.BrandID == nil || *org.BrandID != uint(brandID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "organization is not a branch of this brand"})
+		return
+	}
+	org, err = brandService.Detach(org.ID, uid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, org)
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+var leaderboardService = service.NewLeaderboardService()
+
+type LeaderboardItem struct {
+	Rank             uint                `json:"rank"`
+	OrganizationID   uint                `json:"organization_id"`
+	Name             string              `json:"name"`
+	Description      string              `json:"description"`
+	Phone            *string             `json:"phone"`
+	Website          *string             `json:"website"`
+	OpeningHours     *model.OpeningHours `json:"opening_hours"`
+	QuietHours       *model.OpeningHours `json:"quiet_hours"`
+	Address          string              `json:"address"`
+	OrganizationType string              `json:"organization_type"`
+	Longitude        *float64            `json:"longitude"`
+	Latitude         *float64            `json:"latitude"`
+	Average          float64             `json:"average"`
+	ReviewCount      int64               `json:"review_count"`
+}
+
+type LeaderboardBoard struct {
+	Preset  string            `json:"preset"`
+	Params  []string          `json:"params"`
+	BuiltAt *time.Time        `json:"built_at"`
+	Items   []LeaderboardItem `json:"items"`
+}
+
+type LeaderboardResponse struct {
+	OrganizationType string             `json:"organization_type"`
+	Boards           []LeaderboardBoard `json:"boards"`
+}
+
+// GetLeaderboard godoc
+// @Summary Precomputed top organizations of a type
+// @Description Топ спокойных мест по типу для типовых наборов параметров (overall, quiet, sensory, navigation, staff). Списки предрасчитываются фоновым процессом после изменения оценок, запрос не сканирует организации. Публично.
+// @Tags leaderboards
+// @Produce json
+// @Param type path string true "Organization type"
+// @Param preset query string false "Preset name; all presets if omitted"
+// @Param limit query int false "Max items per board (default 10, max 20)"
+// @Success 200 {object} LeaderboardResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /leaderboards/{type} [get]
+func GetLeaderboard(c *gin.Context) {
+	orgType := organizationTypeService.ResolveOrRaw(c.Param("type"))
+	preset := c.Query("preset")
+	if preset != "" {
+		if _, ok := service.LeaderboardPresets[preset]; !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "unknown preset, expected one of: " + strings.Join(leaderboardService.PresetNames(), ", ")})
+			return
+		}
+	}
+	limit := 10
+	if v := c.Query("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l < 1 || l > 20 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be 1..20"})
+			return
+		}
+		limit = l
+	}
+
+	entries, err := leaderboardService.Get(orgType, preset, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// группируем по preset; пустые доски тоже возвращаем, чтобы клиент видел все наборы
+	names := leaderboardService.PresetNames()
+	if preset != "" {
+		names = []string{preset}
+	}
+	boards := make([]LeaderboardBoard, 0, len(names))
+	index := make(map[string]int, len(names))
+	for _, n := range names {
+		index[n] = len(boards)
+		boards = append(boards, LeaderboardBoard{Preset: n, Params: service.LeaderboardPresets[n], Items: []LeaderboardItem{}})
+	}
+	for _, e := range entries {
+		i, ok := index[e.Preset]
+		if !ok {
+			continue
+		}
+		b := &boards[i]
+		if b.BuiltAt == nil {
+			builtAt := e.BuiltAt
+			b.BuiltAt = &builtAt
+		}
+		b.Items = append(b.Items, LeaderboardItem{
+			Rank:             e.Rank,
+			OrganizationID:   e.OrganizationID,
+			Name:             e.Organization.Name,
+			Description:      e.Organization.Description,
+			Phone:            e.Organization.Phone,
+			Website:          e.Organization.Website,
+			OpeningHours:     e.Organization.OpeningHours,
+			QuietHours:       e.Organization.QuietHours,
+			Address:          e.Organization.Address,
+			OrganizationType: e.Organization.OrganizationType,
+			Longitude:        e.Organization.Longitude,
+			Latitude:         e.Organization.Latitude,
+			Average:          e.Average,
+			ReviewCount:      e.ReviewCount,
+		})
+	}
+
+	c.JSON(http.StatusOK, LeaderboardResponse{OrganizationType: orgType, Boards: boards})
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+var notificationService = service.NewNotificationService()
+
+type NotificationsResponse struct {
+	Items []model.Notification `json:"items"`
+}
+
+// GetMyNotifications godoc
+// @Summary My notifications
+// @Description Уведомления текущего пользователя (новые первыми).
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Param unread query bool false "Only unread"
+// @Param limit query int false "Max items (default 50, max 200)"
+// @Success 200 {object} NotificationsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me/notifications [get]
+func GetMyNotifications(c *gin.Context) {
+	uidRaw, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	var unread bool
+	if v := c.Query("unread"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid unread"})
+			return
+		}
+		unread = b
+	}
+	limit := 50
+	if v := c.Query("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l < 1 || l > 200 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be 1..200"})
+			return
+		}
+		limit = l
+	}
+	list, err := notificationService.List(uidRaw.(uint), unread, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, NotificationsResponse{Items: list})
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark notification as read
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Param notification_id path int true "Notification ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me/notifications/{notification_id}/read [post]
+func MarkNotificationRead(c *gin.Context) {
+	uidRaw, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("notification_id"), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid notification_id"})
+		return
+	}
+	found, err := notificationService.MarkRead(uidRaw.(uint), uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
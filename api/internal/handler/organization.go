@@ -3,7 +3,9 @@ package handler
 import (
 	"errors"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"2gis-calm-map/api/internal/model"
 	"2gis-calm-map/api/internal/service"
@@ -15,17 +17,98 @@ import (
 var organizationService = service.NewOrganizationService()
 
 type OrganizationCreateRequest struct {
-	Address          string   `json:"address" binding:"required"`
-	Longitude        *float64 `json:"longitude"`
-	Latitude         *float64 `json:"latitude"`
-	OrganizationType string   `json:"organization_type" binding:"required"`
+	Name             string              `json:"name" binding:"max=200"`
+	Description      string              `json:"description" binding:"max=5000"`
+	Phone            *string             `json:"phone" binding:"omitempty,max=50"`
+	Website          *string             `json:"website" binding:"omitempty,url,max=500"`
+	OpeningHours     *model.OpeningHours `json:"opening_hours"`
+	QuietHours       *model.OpeningHours `json:"quiet_hours"`
+	Address          string              `json:"address" binding:"required"`
+	Longitude        *float64            `json:"longitude"`
+	Latitude         *float64            `json:"latitude"`
+	OrganizationType string              `json:"organization_type" binding:"required"`
+	// Branch of a brand owned by the caller; owners may create any number of branches of their brands
+	BrandID *uint `json:"brand_id"`
 }
 
 type OrganizationUpdateRequest struct {
-	Address          *string  `json:"address"`
-	Longitude        *float64 `json:"longitude"`
-	Latitude         *float64 `json:"latitude"`
-	OrganizationType *string  `json:"organization_type"`
+	Name             *string             `json:"name" binding:"omitempty,max=200"`
+	Description      *string             `json:"description" binding:"omitempty,max=5000"`
+	Phone            *string             `json:"phone" binding:"omitempty,max=50"`
+	Website          *string             `json:"website" binding:"omitempty,url,max=500"`
+	OpeningHours     *model.OpeningHours `json:"opening_hours"`
+	QuietHours       *model.OpeningHours `json:"quiet_hours"`
+	Address          *string             `json:"address"`
+	Longitude        *float64            `json:"longitude"`
+	Latitude         *float64            `json:"latitude"`
+	OrganizationType *string             `json:"organization_type"`
+	// active | temporarily_closed | closed_permanently
+	Status *string `json:"status" binding:"omitempty,oneof=active temporarily_closed closed_permanently"`
+}
+
+// adminOnly returns caller id for role=admin.
+func adminOnly(c *gin.Context) (uint, bool) {
+	uidRaw, ok := c.Get("user_id")
+	if !ok {
+		return 0, false
+	}
+	role, _ := c.Get("role")
+	if role != "admin" {
+		return 0, false
+	}
+	return uidRaw.(uint), true
+}
+
+// organizationTypeError maps type resolution failure to 400 (unknown type) or 500.
+func organizationTypeError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrUnknownOrganizationType) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error() + " (see GET /organization-types)"})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// openAtFilter resolves "open now" / "open at T" (or "quiet now" / "quiet at T") search options into a moment (nil — no filter).
+func openAtFilter(openNow bool, openAt *time.Time) *time.Time {
+	if openAt != nil {
+		return openAt
+	}
+	if openNow {
+		now := time.Now()
+		return &now
+	}
+	return nil
+}
+
+// parseOpenQuery reads open_now (bool) and open_at (RFC3339) query parameters.
+func parseOpenQuery(c *gin.Context) (*time.Time, error) {
+	return parseMomentQuery(c, "open")
+}
+
+// parseQuietQuery reads quiet_now (bool) and quiet_at (RFC3339) query parameters.
+func parseQuietQuery(c *gin.Context) (*time.Time, error) {
+	return parseMomentQuery(c, "quiet")
+}
+
+// parseMomentQuery reads <prefix>_now and <prefix>_at query parameters.
+func parseMomentQuery(c *gin.Context, prefix string) (*time.Time, error) {
+	var now bool
+	if v := c.Query(prefix + "_now"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errors.New("invalid " + prefix + "_now")
+		}
+		now = b
+	}
+	var at *time.Time
+	if v := c.Query(prefix + "_at"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, errors.New("invalid " + prefix + "_at (expected RFC3339)")
+		}
+		at = &t
+	}
+	return openAtFilter(now, at), nil
 }
 
 func roleAllowed(c *gin.Context) (uint, bool) {
@@ -42,7 +125,7 @@ func roleAllowed(c *gin.Context) (uint, bool) {
 
 // CreateOrganization godoc
 // @Summary Create organization
-// @Description Create organization for current user. Ограничение 1:1 действует ТОЛЬКО для role=owner. Админы (role=admin) могут создавать неограниченно.
+// @Description Create organization for current user. organization_type — slug или название из GET /organization-types. Ограничение 1:1 действует ТОЛЬКО для role=owner и не распространяется на филиалы собственного бренда (brand_id). Админы (role=admin) могут создавать неограниченно.
 // @Tags organization
 // @Accept json
 // @Produce json
@@ -71,9 +154,43 @@ func CreateOrganization(c *gin.Context) {
 		return
 	}
 
-	// Если пользователь НЕ админ, проверяем что у него ещё нет организации
-	if roleStr != "admin" {
-		if _, err := organizationService.GetByOwner(ownerID); err == nil { // нашлась
+	for _, f := range []struct {
+		name  string
+		hours *model.OpeningHours
+	}{{"opening_hours", req.OpeningHours}, {"quiet_hours", req.QuietHours}} {
+		if f.hours == nil {
+			continue
+		}
+		if err := f.hours.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": f.name + ": " + err.Error()})
+			return
+		}
+	}
+	orgType, err := organizationTypeService.Resolve(req.OrganizationType)
+	if err != nil {
+		organizationTypeError(c, err)
+		return
+	}
+
+	if req.BrandID != nil {
+		brand, err := brandService.Get(*req.BrandID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "brand not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if roleStr != "admin" && brand.OwnerID != ownerID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+			return
+		}
+	}
+
+	// Если пользователь НЕ админ, проверяем что у него ещё нет организации (филиалы своего бренда — без ограничения)
+	if roleStr != "admin" && req.BrandID == nil {
+		if _, err := organizationService.GetByOwner(ownerID); err == nil || errors.Is(err, service.ErrOwnerHasManyOrganizations) { // нашлась
 			c.JSON(http.StatusConflict, gin.H{"error": "organization already exists for this owner"})
 			return
 		}
@@ -81,12 +198,19 @@ func CreateOrganization(c *gin.Context) {
 
 	org := model.Organization{
 		OwnerID:          ownerID,
+		Name:             req.Name,
+		Description:      req.Description,
+		Phone:            req.Phone,
+		Website:          req.Website,
+		OpeningHours:     req.OpeningHours,
+		QuietHours:       req.QuietHours,
 		Address:          req.Address,
 		Longitude:        req.Longitude,
 		Latitude:         req.Latitude,
-		OrganizationType: req.OrganizationType,
+		OrganizationType: orgType,
+		BrandID:          req.BrandID,
 	}
-	if err := organizationService.Create(&org); err != nil {
+	if err := organizationService.Create(&org, ownerID); err != nil {
 		// теперь не должно быть unique ошибки, но на всякий случай обрабатываем
 		if strings.Contains(strings.ToLower(err.Error()), "unique") || strings.Contains(strings.ToLower(err.Error()), "duplicate") {
 			c.JSON(http.StatusConflict, gin.H{"error": "organization already exists"})
@@ -100,11 +224,12 @@ func CreateOrganization(c *gin.Context) {
 
 // GetOrganization godoc
 // @Summary Get organization of authenticated owner OR (public) first organization of that owner
-// @Description Публичный доступ: возвращает организацию текущего владельца (если авторизован) или 404 если нет. (Упростили доступ — без ограничения ролей)
+// @Description Публичный доступ: возвращает организацию текущего владельца (если авторизован) или 404 если нет; 409, если у владельца несколько организаций (филиалы) — тогда список см. GET /me/organizations, а к конкретной обращайтесь по id. (Упростили доступ — без ограничения ролей)
 // @Tags organization
 // @Produce json
 // @Success 200 {object} model.Organization
 // @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Router /organization [get]
 func GetOrganization(c *gin.Context) {
 	uidRaw, ok := c.Get("user_id")
@@ -119,15 +244,53 @@ func GetOrganization(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
 			return
 		}
+		if errors.Is(err, service.ErrOwnerHasManyOrganizations) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	pct, err := percentileService.ForOrganizations([]uint{org.ID})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	org.Percentiles = pct[org.ID]
 	c.JSON(http.StatusOK, org)
 }
 
+type MyOrganizationsResponse struct {
+	Items []model.Organization `json:"items"`
+}
+
+// GetMyOrganizations godoc
+// @Summary Organizations of the authenticated owner
+// @Description Все организации текущего пользователя (включая филиалы его бренда), старые первыми. Для владельцев нескольких организаций — замена GET/PATCH /organization (они отвечают 409): выбрать id отсюда и работать через /organization/:organization_id.
+// @Tags organization
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} MyOrganizationsResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me/organizations [get]
+func GetMyOrganizations(c *gin.Context) {
+	uidRaw, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	list, err := organizationService.ListByOwner(uidRaw.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, MyOrganizationsResponse{Items: list})
+}
+
 // PatchOrganization godoc
 // @Summary Update organization
-// @Description Partially update organization (owner/admin)
+// @Description Partially update organization (owner/admin). organization_type — slug или название из GET /organization-types. status — active | temporarily_closed | closed_permanently (закрытые не участвуют в поиске и рейтингах). Если у владельца несколько организаций — 409, используйте PATCH /organization/:organization_id.
 // @Tags organization
 // @Accept json
 // @Produce json
@@ -138,6 +301,7 @@ func GetOrganization(c *gin.Context) {
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /organization [patch]
 func PatchOrganization(c *gin.Context) {
@@ -146,13 +310,93 @@ func PatchOrganization(c *gin.Context) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
+	updates, ok := bindOrganizationUpdates(c)
+	if !ok {
+		return
+	}
+
+	org, err := organizationService.UpdateByOwner(ownerID, updates)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+			return
+		}
+		if errors.Is(err, service.ErrOwnerHasManyOrganizations) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, org)
+}
+
+// PatchOrganizationByID godoc
+// @Summary Update organization by id
+// @Description То же, что PATCH /organization, но для конкретной организации: владелец организации, владелец её бренда (сети) или admin.
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path int true "Organization ID"
+// @Param input body OrganizationUpdateRequest true "Fields to update"
+// @Success 200 {object} model.Organization
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organization/{organization_id} [patch]
+func PatchOrganizationByID(c *gin.Context) {
+	org, uid, ok := managedOrganization(c)
+	if !ok {
+		return
+	}
+	updates, ok := bindOrganizationUpdates(c)
+	if !ok {
+		return
+	}
+	org, err := organizationService.UpdateByID(org.ID, uid, updates)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, org)
+}
 
+// bindOrganizationUpdates reads OrganizationUpdateRequest into column updates; on failure the response is written.
+func bindOrganizationUpdates(c *gin.Context) (map[string]interface{}, bool) {
 	var req OrganizationUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
+		return nil, false
 	}
 	updates := map[string]interface{}{}
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.Phone != nil {
+		updates["phone"] = *req.Phone
+	}
+	if req.Website != nil {
+		updates["website"] = *req.Website
+	}
+	if req.OpeningHours != nil {
+		if err := req.OpeningHours.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "opening_hours: " + err.Error()})
+			return nil, false
+		}
+		updates["opening_hours"] = *req.OpeningHours
+	}
+	if req.QuietHours != nil {
+		if err := req.QuietHours.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "quiet_hours: " + err.Error()})
+			return nil, false
+		}
+		updates["quiet_hours"] = *req.QuietHours
+	}
 	if req.Address != nil {
 		updates["address"] = *req.Address
 	}
@@ -163,21 +407,19 @@ func PatchOrganization(c *gin.Context) {
 		updates["latitude"] = *req.Latitude
 	}
 	if req.OrganizationType != nil {
-		updates["organization_type"] = *req.OrganizationType
+		orgType, err := organizationTypeService.Resolve(*req.OrganizationType)
+		if err != nil {
+			organizationTypeError(c, err)
+			return nil, false
+		}
+		updates["organization_type"] = orgType
+	}
+	if req.Status != nil {
+		updates["status"] = *req.Status
 	}
 	if len(updates) == 0 {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "no fields"})
-		return
-	}
-
-	org, err := organizationService.UpdateByOwner(ownerID, updates)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+		return nil, false
 	}
-	c.JSON(http.StatusOK, org)
+	return updates, true
 }
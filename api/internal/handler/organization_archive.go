@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"2gis-calm-map/api/internal/model"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type OrganizationStatusRequest struct {
+	// active | temporarily_closed | closed_permanently
+	Status string `json:"status" binding:"required,oneof=active temporarily_closed closed_permanently"`
+}
+
+// managedOrganization loads :organization_id and checks that the caller is its owner, owner of its brand or an admin;
+// returns the organization and caller id. On failure the response is already written.
+func managedOrganization(c *gin.Context) (model.Organization, uint, bool) {
+	uid, allowed := roleAllowed(c)
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return model.Organization{}, 0, false
+	}
+	orgID, err := strconv.ParseUint(c.Param("organization_id"), 10, 64)
+	if err != nil || orgID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization_id"})
+		return model.Organization{}, 0, false
+	}
+	org, err := organizationService.GetByID(uint(orgID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "organization not found"})
+			return org, 0, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return org, 0, false
+	}
+	role, _ := c.Get("role")
+	if role != "admin" && org.OwnerID != uid && !brandService.Manages(uid, org.BrandID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return org, 0, false
+	}
+	return org, uid, true
+}
+
+// SetOrganizationStatus godoc
+// @Summary Change organization status
+// @Description Архивация: temporarily_closed / closed_permanently убирают организацию из поиска, рейтингов и рекомендаций; отзывы и агрегаты сохраняются. active — вернуть. Владелец организации или admin.
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path int true "Organization ID"
+// @Param input body OrganizationStatusRequest true "Status"
+// @Success 200 {object} model.Organization
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organization/{organization_id}/status [post]
+func SetOrganizationStatus(c *gin.Context) {
+	org, uid, ok := managedOrganization(c)
+	if !ok {
+		return
+	}
+	var req OrganizationStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	org, err := organizationService.SetStatus(org.ID, req.Status, uid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, org)
+}
+
+// DeleteOrganization godoc
+// @Summary Delete organization (soft)
+// @Description Мягкое удаление: организация пропадает из всех публичных эндпоинтов, отзывы и агрегаты остаются и доступны админам (GET /organization/{id}/comments). Восстановление — POST /organization/{id}/restore (admin). Владелец организации или admin.
+// @Tags organization
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path int true "Organization ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organization/{organization_id} [delete]
+func DeleteOrganization(c *gin.Context) {
+	org, uid, ok := managedOrganization(c)
+	if !ok {
+		return
+	}
+	if err := organizationService.Delete(org.ID, uid); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RestoreOrganization godoc
+// @Summary Restore deleted organization (admin)
+// @Tags organization
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path int true "Organization ID"
+// @Success 200 {object} model.Organization
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organization/{organization_id}/restore [post]
+func RestoreOrganization(c *gin.Context) {
+	uid, ok := adminOnly(c)
+	if !ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	orgID, err := strconv.ParseUint(c.Param("organization_id"), 10, 64)
+	if err != nil || orgID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization_id"})
+		return
+	}
+	org, err := organizationService.Restore(uint(orgID), uid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "organization not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, org)
+}
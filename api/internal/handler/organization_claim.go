@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var organizationClaimService = service.NewOrganizationClaimService()
+
+type OrganizationClaimCreateRequest struct {
+	Evidence string `json:"evidence" binding:"required,max=5000"` // документы, ссылки, должность и т.п.
+	Contact  string `json:"contact" binding:"max=200"`
+}
+
+type OrganizationClaimReviewRequest struct {
+	Note string `json:"note" binding:"max=2000"`
+}
+
+type OrganizationClaimsResponse struct {
+	Items []model.OrganizationClaim `json:"items"`
+}
+
+// claimError maps claim workflow errors to HTTP statuses.
+func claimError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	case errors.Is(err, service.ErrClaimAlreadyOwner), errors.Is(err, service.ErrClaimDuplicate),
+		errors.Is(err, service.ErrClaimantHasOrganization), errors.Is(err, service.ErrClaimNotPending):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// CreateOrganizationClaim godoc
+// @Summary Claim an existing organization
+// @Description Владелец (role=owner/admin) запрашивает управление существующей организацией, прикладывая подтверждение (evidence). Заявка получает статус pending, админы получают уведомление. Владелец с ролью owner может управлять только одной организацией.
+// @Tags organization-claims
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path int true "Organization ID"
+// @Param input body OrganizationClaimCreateRequest true "Claim"
+// @Success 200 {object} model.OrganizationClaim
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organization/{organization_id}/claims [post]
+func CreateOrganizationClaim(c *gin.Context) {
+	uid, allowed := roleAllowed(c)
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	orgID, err := strconv.ParseUint(c.Param("organization_id"), 10, 64)
+	if err != nil || orgID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization_id"})
+		return
+	}
+	var req OrganizationClaimCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	role, _ := c.Get("role")
+	roleStr, _ := role.(string)
+	claim, err := organizationClaimService.Submit(uint(orgID), model.User{ID: uid, Role: roleStr}, req.Evidence, req.Contact)
+	if err != nil {
+		claimError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, claim)
+}
+
+// GetMyOrganizationClaims godoc
+// @Summary My organization claims
+// @Description Заявки текущего пользователя с историей статусов.
+// @Tags organization-claims
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} OrganizationClaimsResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me/claims [get]
+func GetMyOrganizationClaims(c *gin.Context) {
+	uidRaw, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	list, err := organizationClaimService.ListByClaimant(uidRaw.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, OrganizationClaimsResponse{Items: list})
+}
+
+// ListOrganizationClaims godoc
+// @Summary List organization claims (admin)
+// @Description Все заявки (по умолчанию — ожидающие рассмотрения) с историей статусов. Только admin.
+// @Tags organization-claims
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "pending (default) | approved | rejected | all"
+// @Success 200 {object} OrganizationClaimsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /claims [get]
+func ListOrganizationClaims(c *gin.Context) {
+	if _, ok := adminOnly(c); !ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	status := c.DefaultQuery("status", model.ClaimStatusPending)
+	switch status {
+	case "all":
+		status = ""
+	case model.ClaimStatusPending, model.ClaimStatusApproved, model.ClaimStatusRejected:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+		return
+	}
+	list, err := organizationClaimService.List(status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, OrganizationClaimsResponse{Items: list})
+}
+
+func reviewOrganizationClaim(c *gin.Context, approve bool) {
+	adminID, ok := adminOnly(c)
+	if !ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	claimID, err := strconv.ParseUint(c.Param("claim_id"), 10, 64)
+	if err != nil || claimID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid claim_id"})
+		return
+	}
+	var req OrganizationClaimReviewRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	claim, err := organizationClaimService.Review(uint(claimID), adminID, approve, req.Note)
+	if err != nil {
+		claimError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, claim)
+}
+
+// ApproveOrganizationClaim godoc
+// @Summary Approve organization claim (admin)
+// @Description Передаёт организацию заявителю (OwnerID), остальные ожидающие заявки на неё отклоняются. Заявитель, прежний владелец и отклонённые заявители получают уведомления. Только admin.
+// @Tags organization-claims
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param claim_id path int true "Claim ID"
+// @Param input body OrganizationClaimReviewRequest false "Review note"
+// @Success 200 {object} model.OrganizationClaim
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /claims/{claim_id}/approve [post]
+func ApproveOrganizationClaim(c *gin.Context) { reviewOrganizationClaim(c, true) }
+
+// RejectOrganizationClaim godoc
+// @Summary Reject organization claim (admin)
+// @Description Отклоняет заявку, заявитель получает уведомление с комментарием. Только admin.
+// @Tags organization-claims
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param claim_id path int true "Claim ID"
+// @Param input body OrganizationClaimReviewRequest false "Review note"
+// @Success 200 {object} model.OrganizationClaim
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /claims/{claim_id}/reject [post]
+func RejectOrganizationClaim(c *gin.Context) { reviewOrganizationClaim(c, false) }
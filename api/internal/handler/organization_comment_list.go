@@ -3,6 +3,9 @@ package handler
 import (
 	"fmt"
 	"net/http"
+	"time"
+
+	"2gis-calm-map/api/internal/model"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -10,11 +13,14 @@ import (
 
 // Response item for comment list
 type OrganizationCommentListItem struct {
-	ID       uint     `json:"id"`
-	UserID   uint     `json:"user_id"`
-	UserName string   `json:"user_name"`
-	Text     *string  `json:"text"`
-	AvgValue *float64 `json:"avg_val"`
+	ID        uint       `json:"id"`
+	UserID    uint       `json:"user_id"`
+	UserName  string     `json:"user_name"`
+	Text      *string    `json:"text"`
+	AvgValue  *float64   `json:"avg_val"`
+	CreatedAt *time.Time `json:"created_at"`
+	// Ответ организации (владелец или admin), nil — ответа нет
+	Response *model.OrganizationCommentResponse `json:"response"`
 }
 
 type OrganizationCommentListResponse struct {
@@ -24,7 +30,7 @@ type OrganizationCommentListResponse struct {
 
 // GetOrganizationComments godoc
 // @Summary List comments for organization
-// @Description Возвращает список комментариев организации с автором и средней оценкой.
+// @Description Возвращает список комментариев организации с автором, средней оценкой и ответом организации. Отзывы удалённой организации доступны только admin.
 // @Tags organization-comments
 // @Accept json
 // @Produce json
@@ -48,8 +54,12 @@ func GetOrganizationComments(c *gin.Context) {
 		return
 	}
 
-	// ensure org exists
-	if _, err := orgService.GetByID(orgID); err != nil {
+	// ensure org exists; admins also see reviews of soft-deleted organizations
+	getOrg := orgService.GetByID
+	if role, _ := c.Get("role"); role == "admin" {
+		getOrg = orgService.GetByIDUnscoped
+	}
+	if _, err := getOrg(orgID); err != nil {
 		if err == gorm.ErrRecordNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "organization not found"})
 			return
@@ -64,17 +74,22 @@ func GetOrganizationComments(c *gin.Context) {
 		return
 	}
 
+	c.JSON(http.StatusOK, OrganizationCommentListResponse{OrganizationID: orgID, Items: commentListItems(list)})
+}
+
+// commentListItems converts comments (with User and Response preloaded) to list items.
+func commentListItems(list []model.OrganizationComment) []OrganizationCommentListItem {
 	items := make([]OrganizationCommentListItem, 0, len(list))
 	for _, cmt := range list {
-		name := cmt.User.Name
 		items = append(items, OrganizationCommentListItem{
-			ID:       cmt.ID,
-			UserID:   cmt.UserID,
-			UserName: name,
-			Text:     cmt.Text,
-			AvgValue: cmt.AvgValue,
+			ID:        cmt.ID,
+			UserID:    cmt.UserID,
+			UserName:  cmt.User.Name,
+			Text:      cmt.Text,
+			AvgValue:  cmt.AvgValue,
+			CreatedAt: cmt.CreatedAt,
+			Response:  cmt.Response,
 		})
 	}
-
-	c.JSON(http.StatusOK, OrganizationCommentListResponse{OrganizationID: orgID, Items: items})
+	return items
 }
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var commentResponseService = service.NewOrganizationCommentResponseService()
+
+type OrganizationCommentResponseRequest struct {
+	Text string `json:"text" binding:"required,max=5000"`
+}
+
+// respondingOrganization loads :organization_id and :comment_id; replying is allowed to the organization's
+// OwnerID and admins only. On failure the response is already written.
+func respondingOrganization(c *gin.Context) (model.Organization, uint, uint, bool) {
+	uidRaw, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return model.Organization{}, 0, 0, false
+	}
+	uid := uidRaw.(uint)
+	orgID, err := strconv.ParseUint(c.Param("organization_id"), 10, 64)
+	if err != nil || orgID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization_id"})
+		return model.Organization{}, 0, 0, false
+	}
+	commentID, err := strconv.ParseUint(c.Param("comment_id"), 10, 64)
+	if err != nil || commentID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment_id"})
+		return model.Organization{}, 0, 0, false
+	}
+	org, err := organizationService.GetByID(uint(orgID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "organization not found"})
+			return org, 0, 0, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return org, 0, 0, false
+	}
+	if role, _ := c.Get("role"); role != "admin" && org.OwnerID != uid {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return org, 0, 0, false
+	}
+	return org, uint(commentID), uid, true
+}
+
+// PutOrganizationCommentResponse godoc
+// @Summary Reply to a review
+// @Description Ответ организации на отзыв: один на отзыв, повторный вызов редактирует текст. Только владелец организации (OwnerID) или admin. При первом ответе автор отзыва получает уведомление (kind=comment_response).
+// @Tags organization-comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path int true "Organization ID"
+// @Param comment_id path int true "Comment ID"
+// @Param input body OrganizationCommentResponseRequest true "Reply"
+// @Success 200 {object} model.OrganizationCommentResponse
+// @Success 201 {object} model.OrganizationCommentResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organization/{organization_id}/comments/{comment_id}/response [put]
+func PutOrganizationCommentResponse(c *gin.Context) {
+	org, commentID, uid, ok := respondingOrganization(c)
+	if !ok {
+		return
+	}
+	var req OrganizationCommentResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	resp, created, err := commentResponseService.Respond(org, commentID, uid, req.Text)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "comment not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, resp)
+}
+
+// DeleteOrganizationCommentResponse godoc
+// @Summary Delete reply to a review
+// @Description Только владелец организации (OwnerID) или admin.
+// @Tags organization-comments
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path int true "Organization ID"
+// @Param comment_id path int true "Comment ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organization/{organization_id}/comments/{comment_id}/response [delete]
+func DeleteOrganizationCommentResponse(c *gin.Context) {
+	org, commentID, _, ok := respondingOrganization(c)
+	if !ok {
+		return
+	}
+	deleted, err := commentResponseService.Delete(org.ID, commentID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusNotFound, gin.H{"error": "response not found"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	compareMinOrganizations = 2
+	compareMaxOrganizations = 10
+)
+
+type OrganizationCompareItem struct {
+	ID                uint                               `json:"id"`
+	Name              string                             `json:"name"`
+	Description       string                             `json:"description"`
+	Phone             *string                            `json:"phone"`
+	Website           *string                            `json:"website"`
+	OpeningHours      *model.OpeningHours                `json:"opening_hours"`
+	QuietHours        *model.OpeningHours                `json:"quiet_hours"`
+	Address           string                             `json:"address"`
+	OrganizationType  string                             `json:"organization_type"`
+	Factors           map[string]OrganizationParamFactor `json:"factors"`
+	PersonalScore     *float64                           `json:"personal_score"` // null если нет токена или не выбраны параметры
+	PersonalBreakdown []service.ParamBreakdown           `json:"personal_breakdown,omitempty"`
+	BestFactor        *string                            `json:"best_factor"`
+	WorstFactor       *string                            `json:"worst_factor"`
+}
+
+type OrganizationCompareResponse struct {
+	PersonalParams []string                  `json:"personal_params"`
+	Items          []OrganizationCompareItem `json:"items"`
+}
+
+// parseIDList accepts ids=1,2,3 as well as repeated ids=1&ids=2.
+func parseIDList(values []string) ([]uint, error) {
+	var ids []uint
+	seen := map[uint]bool{}
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			id, err := strconv.ParseUint(part, 10, 64)
+			if err != nil || id == 0 {
+				return nil, errors.New("invalid id: " + part)
+			}
+			if !seen[uint(id)] {
+				seen[uint(id)] = true
+				ids = append(ids, uint(id))
+			}
+		}
+	}
+	return ids, nil
+}
+
+// CompareOrganizations godoc
+// @Summary Side-by-side comparison of organizations
+// @Description Возвращает avg/count по всем параметрам, лучший и худший параметр для каждой организации. Если передан токен — добавляет персональную оценку по параметрам из UserParams. От 2 до 10 организаций.
+// @Tags organization-params
+// @Produce json
+// @Param ids query string true "Comma-separated organization IDs, e.g. 1,2,3"
+// @Param aggregate query string false "plain (default) | sensitivity"
+// @Success 200 {object} OrganizationCompareResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organization/compare [get]
+func CompareOrganizations(c *gin.Context) {
+	ids, err := parseIDList(c.QueryArray("ids"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	aggregate := c.Query("aggregate")
+	if !model.IsAggregate(aggregate) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "aggregate must be plain or sensitivity"})
+		return
+	}
+	if len(ids) < compareMinOrganizations || len(ids) > compareMaxOrganizations {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ids must contain from 2 to 10 organizations"})
+		return
+	}
+
+	// персональные параметры — только при наличии валидного токена
+	var personal []string
+	if uidRaw, ok := c.Get("user_id"); ok {
+		up, err := userParamsService.GetUserParamsByUserID(uidRaw.(uint))
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		personal = up.SelectedParams()
+	}
+
+	orgs, err := orgService.GetByIDs(ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	byID := make(map[uint]model.Organization, len(orgs))
+	for _, o := range orgs {
+		byID[o.ID] = o
+	}
+	pct, err := percentileService.ForOrganizations(ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	items := make([]OrganizationCompareItem, 0, len(ids))
+	for _, id := range ids {
+		org, ok := byID[id]
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": "organization not found: " + strconv.FormatUint(uint64(id), 10)})
+			return
+		}
+		p := model.OrganizationParams{OrganizationID: id}
+		if org.Params != nil {
+			p = *org.Params
+		}
+		p = p.WithAggregate(aggregate)
+		item := OrganizationCompareItem{
+			ID:               org.ID,
+			Name:             org.Name,
+			Description:      org.Description,
+			Phone:            org.Phone,
+			Website:          org.Website,
+			OpeningHours:     org.OpeningHours,
+			QuietHours:       org.QuietHours,
+			Address:          org.Address,
+			OrganizationType: org.OrganizationType,
+			Factors:          make(map[string]OrganizationParamFactor, len(model.ParamNames)),
+		}
+		for _, name := range model.ParamNames {
+			avg, count, _ := p.Factor(name)
+			item.Factors[name] = OrganizationParamFactor{Avg: avg, Count: count, Percentile: percentilePtr(pct[id], name)}
+		}
+		if len(personal) > 0 {
+			score, breakdown, err := orgParamsService.ExplainAverage(p, personal, nil)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			item.PersonalScore = &score
+			item.PersonalBreakdown = withPercentiles(breakdown, pct[id])
+		}
+		if best, worst, ok := orgParamsService.BestWorstFactors(p); ok {
+			item.BestFactor = &best
+			item.WorstFactor = &worst
+		}
+		items = append(items, item)
+	}
+
+	c.JSON(http.StatusOK, OrganizationCompareResponse{PersonalParams: personal, Items: items})
+}
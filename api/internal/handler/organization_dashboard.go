@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"2gis-calm-map/api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizationDashboardResponse struct {
+	OrganizationID uint `json:"organization_id"`
+	service.OrganizationDashboard
+	Recent []OrganizationCommentListItem `json:"recent"`
+}
+
+// GetOrganizationDashboard godoc
+// @Summary Owner analytics dashboard
+// @Description Аналитика отзывов организации: динамика числа отзывов по дням/неделям/месяцам (пустые периоды включены). Отзывы, оставленные до появления даты у отзывов (created_at = null), входят в total_reviews, но не в volume: их число отдаётся в undated_reviews и в динамике они не появятся никогда, средние по факторам в сравнении со средними по всем активным организациям того же типа, самые слабые факторы, частые слова в комментариях к факторам (всего и по каждому фактору) и последние отзывы. Владелец организации, владелец бренда или admin.
+// @Tags organization
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path int true "Organization ID"
+// @Param period query string false "Volume bucket: day | week (default) | month"
+// @Param periods query int false "Number of last periods (default 12, max 366)"
+// @Param words query int false "Top words size (default 20, max 100)"
+// @Param recent query int false "Recent reviews (default 10, max 50)"
+// @Success 200 {object} OrganizationDashboardResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organization/{organization_id}/dashboard [get]
+func GetOrganizationDashboard(c *gin.Context) {
+	org, _, ok := managedOrganization(c)
+	if !ok {
+		return
+	}
+	opts := service.DashboardOptions{Period: c.DefaultQuery("period", service.DashboardPeriodWeek)}
+	switch opts.Period {
+	case service.DashboardPeriodDay, service.DashboardPeriodWeek, service.DashboardPeriodMonth:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "period must be day, week or month"})
+		return
+	}
+	for _, q := range []struct {
+		name string
+		max  int
+		dst  *int
+	}{
+		{"periods", 366, &opts.Periods},
+		{"words", 100, &opts.Words},
+		{"recent", 50, &opts.Recent},
+	} {
+		v := c.Query(q.name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > q.max {
+			c.JSON(http.StatusBadRequest, gin.H{"error": q.name + " must be 1.." + strconv.Itoa(q.max)})
+			return
+		}
+		*q.dst = n
+	}
+
+	d, err := organizationService.Dashboard(org, opts, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, OrganizationDashboardResponse{
+		OrganizationID:        org.ID,
+		OrganizationDashboard: d,
+		Recent:                commentListItems(d.Recent),
+	})
+}
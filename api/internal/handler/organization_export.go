@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"2gis-calm-map/api/internal/repository"
+	"2gis-calm-map/api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportWriter sets the response headers on the first write, so errors before any data can still be sent as JSON.
+type exportWriter struct {
+	c           *gin.Context
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.c.Header("Content-Type", w.contentType)
+		w.c.Header("Content-Disposition", `attachment; filename="`+w.filename+`"`)
+		w.c.Status(http.StatusOK)
+	}
+	return w.c.Writer.Write(p)
+}
+
+// ExportOrganizationsGeoJSON godoc
+// @Summary Export organizations as GeoJSON
+// @Description Потоковая выгрузка организаций с координатами в GeoJSON FeatureCollection (например, для QGIS): точка, тип, статус, бренд и средние по каждому фактору (<param> — среднее, null если оценок нет; <param>_count — число оценок) в properties. Фильтры те же, что у GET /organization/search; q необязателен. Закрытые организации исключаются, если не передан include_archived. Публично.
+// @Tags organization
+// @Produce json
+// @Param q query string false "Search text"
+// @Param type query string false "Organization type (slug or name)"
+// @Param min query string false "Per-factor minimums, e.g. smell:4,lighting:3.5"
+// @Param aggregate query string false "plain (default) | sensitivity"
+// @Param lat query number false "Latitude of the center (with lon and radius)"
+// @Param lon query number false "Longitude of the center"
+// @Param radius query number false "Radius in meters"
+// @Param open_now query bool false "Only organizations open now"
+// @Param open_at query string false "Only organizations open at this moment (RFC3339)"
+// @Param quiet_now query bool false "Only organizations in quiet hours now"
+// @Param quiet_at query string false "Only organizations in quiet hours at this moment (RFC3339)"
+// @Param include_archived query bool false "Include temporarily/permanently closed organizations"
+// @Param brand query int false "Only branches of this brand (id)"
+// @Param amenities query string false "Comma-separated required amenity slugs (see GET /amenities)"
+// @Success 200 {object} map[string]interface{} "GeoJSON FeatureCollection"
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /export/organizations.geojson [get]
+func ExportOrganizationsGeoJSON(c *gin.Context) {
+	exportOrganizations(c, service.ExportFormatGeoJSON, "application/geo+json", "organizations.geojson")
+}
+
+// ExportOrganizationsKML godoc
+// @Summary Export organizations as KML
+// @Description Та же выгрузка, что /export/organizations.geojson, в формате KML: Placemark с точкой, адресом, описанием и атрибутами (включая средние по факторам) в ExtendedData. Публично.
+// @Tags organization
+// @Produce application/vnd.google-earth.kml+xml
+// @Param q query string false "Search text"
+// @Param type query string false "Organization type (slug or name)"
+// @Param min query string false "Per-factor minimums, e.g. smell:4,lighting:3.5"
+// @Param aggregate query string false "plain (default) | sensitivity"
+// @Param lat query number false "Latitude of the center (with lon and radius)"
+// @Param lon query number false "Longitude of the center"
+// @Param radius query number false "Radius in meters"
+// @Param open_now query bool false "Only organizations open now"
+// @Param open_at query string false "Only organizations open at this moment (RFC3339)"
+// @Param quiet_now query bool false "Only organizations in quiet hours now"
+// @Param quiet_at query string false "Only organizations in quiet hours at this moment (RFC3339)"
+// @Param include_archived query bool false "Include temporarily/permanently closed organizations"
+// @Param brand query int false "Only branches of this brand (id)"
+// @Param amenities query string false "Comma-separated required amenity slugs (see GET /amenities)"
+// @Success 200 {string} string "KML document"
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /export/organizations.kml [get]
+func ExportOrganizationsKML(c *gin.Context) {
+	exportOrganizations(c, service.ExportFormatKML, "application/vnd.google-earth.kml+xml", "organizations.kml")
+}
+
+func exportOrganizations(c *gin.Context, format, contentType, filename string) {
+	q := repository.OrganizationSearchQuery{Text: strings.TrimSpace(c.Query("q"))}
+	if !bindSearchFilters(c, &q) {
+		return
+	}
+	w := &exportWriter{c: c, contentType: contentType, filename: filename}
+	started, err := organizationSearchService.Export(q, format, w)
+	if err == nil {
+		return
+	}
+	if started {
+		// заголовки уже отправлены: сбрасываем соединение, чтобы клиент увидел ошибку, а не принял обрезанный документ за полный
+		log.Println("warn: organization export interrupted:", err)
+		panic(http.ErrAbortHandler)
+	}
+	if errors.Is(err, service.ErrUnknownParam) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
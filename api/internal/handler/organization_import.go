@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"2gis-calm-map/api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// importMaxBytes limits the uploaded dataset size.
+const importMaxBytes = 20 << 20
+
+// ImportOrganizations godoc
+// @Summary Bulk import organizations (admin)
+// @Description Импорт организаций из CSV (заголовок обязателен, разделитель «,» или «;»; колонки name, description, phone, website, address, lat, lon, organization_type, brand_id, opening_hours и quiet_hours в JSON) или GeoJSON FeatureCollection точек (те же имена в properties). Файл — multipart-поле file или тело запроса. Каждая строка проверяется как в POST /organization; дубли (тот же нормализованный адрес или точка ближе 25 м при совпадающем названии — среди существующих организаций и ранее в файле) пропускаются. По умолчанию строки создаются по отдельности с отчётом по каждой; atomic=true — всё или ничего в одной транзакции; dry_run=true — только проверка. Владелец созданных организаций — вызывающий admin.
+// @Tags organization
+// @Accept multipart/form-data
+// @Accept text/csv
+// @Accept application/geo+json
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file false "CSV or GeoJSON file"
+// @Param format query string false "csv | geojson (default: by file extension or Content-Type)"
+// @Param dry_run query bool false "Validate only"
+// @Param atomic query bool false "All or nothing"
+// @Success 200 {object} service.ImportResult
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organization/import [post]
+func ImportOrganizations(c *gin.Context) {
+	uid, ok := adminOnly(c)
+	if !ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	opts := service.ImportOptions{OwnerID: uid}
+	for name, dst := range map[string]*bool{"dry_run": &opts.DryRun, "atomic": &opts.Atomic} {
+		if v := c.Query(name); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+				return
+			}
+			*dst = b
+		}
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, importMaxBytes)
+	var body io.Reader = c.Request.Body
+	format := c.Query("format")
+	if c.ContentType() == "multipart/form-data" {
+		file, header, err := c.Request.FormFile("file")
+		if err != nil {
+			importBodyError(c, err, "file required")
+			return
+		}
+		defer file.Close()
+		body = file
+		if format == "" {
+			format = service.ImportFormatOf(header.Filename, header.Header.Get("Content-Type"))
+		}
+	} else if format == "" {
+		format = service.ImportFormatOf("", c.ContentType())
+	}
+
+	rows, err := service.ParseImport(format, body)
+	if err != nil {
+		importBodyError(c, err, err.Error())
+		return
+	}
+	res, err := organizationService.Import(rows, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// importBodyError answers 413 if the upload exceeded importMaxBytes, 400 with msg otherwise.
+func importBodyError(c *gin.Context, err error, msg string) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file is too large"})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+}
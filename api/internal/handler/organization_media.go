@@ -9,8 +9,6 @@ import (
 	"strings"
 	"time"
 
-	"2gis-calm-map/api/internal/repository"
-
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
@@ -107,8 +105,9 @@ func uploadOrganizationImage(c *gin.Context, kind string) {
 		updates["picture_path"] = relPath
 	}
 
-	// direct update via repository
-	if err := repository.UpdateOrganizationFields(org.ID, updates); err != nil {
+	uid, _ := c.Get("user_id")
+	actorID, _ := uid.(uint)
+	if err := orgService.UpdateMedia(org.ID, actorID, updates); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -9,21 +9,43 @@ import (
 )
 
 var orgParamsService = service.NewOrganizationParamsService()
+var percentileService = service.NewOrganizationPercentileService()
+
+// percentilePtr returns percentile of a param or nil if it is not computed.
+func percentilePtr(pct map[string]float64, name string) *float64 {
+	v, ok := pct[name]
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// withPercentiles fills Percentile of every breakdown entry.
+func withPercentiles(breakdown []service.ParamBreakdown, pct map[string]float64) []service.ParamBreakdown {
+	for i := range breakdown {
+		breakdown[i].Percentile = percentilePtr(pct, breakdown[i].Param)
+	}
+	return breakdown
+}
 
 type OrganizationParamsAverageRequest struct {
 	OrganizationID uint     `json:"organization_id" binding:"required"`
 	Params         []string `json:"params" binding:"required,min=1"`
+	// plain (default) | sensitivity — среднее, где оценки авторов, отметивших параметр важным, весят больше
+	Aggregate string `json:"aggregate" binding:"omitempty,oneof=plain sensitivity"`
 }
 
 type OrganizationParamsAverageResponse struct {
 	OrganizationID uint     `json:"organization_id"`
 	Params         []string `json:"params"`
 	Average        float64  `json:"average"`
+	// Breakdown по каждому запрошенному параметру: avg, count, вес, учтён ли и почему нет
+	Breakdown []service.ParamBreakdown `json:"breakdown"`
 }
 
 // GetOrganizationParamsAverage godoc
 // @Summary Compute average across selected organization params
-// @Description Returns (avg(param1)+...)/N for specified params. Public access (без проверки роли).
+// @Description Returns (avg(param1)+...)/N for specified params with per-param breakdown (excluded params have reason not_rated). Public access (без проверки роли).
 // @Tags organization-params
 // @Accept json
 // @Produce json
@@ -43,20 +65,26 @@ func GetOrganizationParamsAverage(c *gin.Context) {
 
 	// публично: не фильтруем по ролям
 
-	paramsModel, err := orgParamsService.GetOrCreate(req.OrganizationID)
+	paramsModel, err := orgParamsService.Get(req.OrganizationID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	avg, err := orgParamsService.ComputeAverageAcross(paramsModel, req.Params)
+	avg, breakdown, err := orgParamsService.ExplainAverage(paramsModel.WithAggregate(req.Aggregate), req.Params, nil)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	pct, err := percentileService.ForOrganizations([]uint{req.OrganizationID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 	c.JSON(http.StatusOK, OrganizationParamsAverageResponse{
 		OrganizationID: req.OrganizationID,
 		Params:         req.Params,
 		Average:        avg,
+		Breakdown:      withPercentiles(breakdown, pct[req.OrganizationID]),
 	})
 }
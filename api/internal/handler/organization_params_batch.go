@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// paramsBatchMaxSize limits organization_ids per batch request (see SetParamsBatchMaxSize).
+var paramsBatchMaxSize = 200
+
+// SetParamsBatchMaxSize overrides the batch limit (PARAMS_BATCH_MAX_SIZE, read once at startup); n <= 0 is ignored.
+func SetParamsBatchMaxSize(n int) {
+	if n > 0 {
+		paramsBatchMaxSize = n
+	}
+}
+
+// OrganizationParamsBatchRequest: либо params (простое среднее), либо weights (взвешенное).
+type OrganizationParamsBatchRequest struct {
+	OrganizationIDs []uint             `json:"organization_ids" binding:"required,min=1"`
+	Params          []string           `json:"params"`
+	Weights         map[string]float64 `json:"weights" binding:"omitempty,dive,gte=0"`
+	// plain (default) | sensitivity — среднее, где оценки авторов, отметивших параметр важным, весят больше
+	Aggregate string `json:"aggregate" binding:"omitempty,oneof=plain sensitivity"`
+}
+
+type OrganizationParamFactor struct {
+	Avg        float64  `json:"avg"`
+	Count      uint     `json:"count"`
+	Percentile *float64 `json:"percentile,omitempty"` // среди организаций того же типа
+}
+
+type OrganizationParamsBatchItem struct {
+	OrganizationID uint                               `json:"organization_id"`
+	Average        float64                            `json:"average"`
+	Factors        map[string]OrganizationParamFactor `json:"factors"`
+	Breakdown      []service.ParamBreakdown           `json:"breakdown"`
+}
+
+type OrganizationParamsBatchResponse struct {
+	Params   []string                      `json:"params,omitempty"`
+	Weights  map[string]float64            `json:"weights,omitempty"`
+	Items    []OrganizationParamsBatchItem `json:"items"`
+	NotFound []uint                        `json:"not_found"`
+}
+
+// GetOrganizationParamsAverageBatch godoc
+// @Summary Compute selected average for many organizations at once
+// @Description Для списка organization_ids возвращает среднее по params (или взвешенное по weights), а также avg/count по каждому выбранному параметру. Один запрос к БД. Максимальный размер пачки задаётся PARAMS_BATCH_MAX_SIZE (по умолчанию 200). Публично.
+// @Tags organization-params
+// @Accept json
+// @Produce json
+// @Param input body OrganizationParamsBatchRequest true "Batch request"
+// @Success 200 {object} OrganizationParamsBatchResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organization/params/average/batch [post]
+func GetOrganizationParamsAverageBatch(c *gin.Context) {
+	var req OrganizationParamsBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if (len(req.Params) == 0) == (len(req.Weights) == 0) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "exactly one of params or weights is required"})
+		return
+	}
+	if limit := paramsBatchMaxSize; len(req.OrganizationIDs) > limit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("too many organization_ids (max %d)", limit)})
+		return
+	}
+
+	// выбранные параметры в каноническом виде — для factors в ответе
+	selected := req.Params
+	if len(req.Weights) > 0 {
+		selected = make([]string, 0, len(req.Weights))
+		for name := range req.Weights {
+			selected = append(selected, name)
+		}
+	}
+	names, err := orgParamsService.NormalizeParams(selected)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// убираем дубликаты, сохраняя порядок
+	ids := make([]uint, 0, len(req.OrganizationIDs))
+	seen := make(map[uint]bool, len(req.OrganizationIDs))
+	for _, id := range req.OrganizationIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	orgs, err := orgService.GetByIDs(ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	byID := make(map[uint]model.Organization, len(orgs))
+	for _, o := range orgs {
+		byID[o.ID] = o
+	}
+	pct, err := percentileService.ForOrganizations(ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := OrganizationParamsBatchResponse{
+		Params:   req.Params,
+		Weights:  req.Weights,
+		Items:    make([]OrganizationParamsBatchItem, 0, len(ids)),
+		NotFound: []uint{},
+	}
+	for _, id := range ids {
+		org, ok := byID[id]
+		if !ok {
+			resp.NotFound = append(resp.NotFound, id)
+			continue
+		}
+		p := model.OrganizationParams{OrganizationID: id}
+		if org.Params != nil {
+			p = *org.Params
+		}
+		p = p.WithAggregate(req.Aggregate)
+		var weights map[string]float64
+		if len(req.Weights) > 0 {
+			weights = req.Weights
+		}
+		avg, breakdown, err := orgParamsService.ExplainAverage(p, req.Params, weights)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		factors := make(map[string]OrganizationParamFactor, len(names))
+		for _, name := range names {
+			fAvg, fCount, _ := p.Factor(name)
+			factors[name] = OrganizationParamFactor{Avg: fAvg, Count: fCount, Percentile: percentilePtr(pct[id], name)}
+		}
+		resp.Items = append(resp.Items, OrganizationParamsBatchItem{OrganizationID: id, Average: avg, Factors: factors, Breakdown: withPercentiles(breakdown, pct[id])})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
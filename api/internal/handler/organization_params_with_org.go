@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 
+	"2gis-calm-map/api/internal/model"
 	"2gis-calm-map/api/internal/repository"
 	"2gis-calm-map/api/internal/service"
 
@@ -15,20 +16,31 @@ var orgParamsWithOrgService = service.NewOrganizationParamsService()
 type OrganizationParamsWithOrgRequest struct {
 	OrganizationID uint     `json:"organization_id" binding:"required"`
 	Params         []string `json:"params" binding:"required,min=1"`
+	// plain (default) | sensitivity — среднее, где оценки авторов, отметивших параметр важным, весят больше
+	Aggregate string `json:"aggregate" binding:"omitempty,oneof=plain sensitivity"`
 }
 
 type OrganizationParamsWithOrgResponse struct {
 	Organization struct {
-		ID               uint     `json:"id"`
-		Address          string   `json:"address"`
-		OrganizationType string   `json:"organization_type"`
-		Longitude        *float64 `json:"longitude"`
-		Latitude         *float64 `json:"latitude"`
-		MapPath          *string  `json:"map_path"`
-		PicturePath      *string  `json:"picture_path"`
+		ID               uint                `json:"id"`
+		Name             string              `json:"name"`
+		Description      string              `json:"description"`
+		Phone            *string             `json:"phone"`
+		Website          *string             `json:"website"`
+		OpeningHours     *model.OpeningHours `json:"opening_hours"`
+		QuietHours       *model.OpeningHours `json:"quiet_hours"`
+		Address          string              `json:"address"`
+		OrganizationType string              `json:"organization_type"`
+		Longitude        *float64            `json:"longitude"`
+		Latitude         *float64            `json:"latitude"`
+		MapPath          *string             `json:"map_path"`
+		PicturePath      *string             `json:"picture_path"`
+		// перцентили (0..100) по всем оценённым параметрам среди организаций того же типа
+		Percentiles map[string]float64 `json:"percentiles,omitempty"`
 	} `json:"organization"`
-	Params  []string `json:"params"`
-	Average float64  `json:"average"`
+	Params    []string                 `json:"params"`
+	Average   float64                  `json:"average"`
+	Breakdown []service.ParamBreakdown `json:"breakdown"`
 }
 
 // GetOrganizationParamsAverageWithOrganizationInfo godoc
@@ -56,26 +68,39 @@ func GetOrganizationParamsAverageWithOrganizationInfo(c *gin.Context) {
 		return
 	}
 
-	paramsModel, err := orgParamsWithOrgService.GetOrCreate(req.OrganizationID)
+	paramsModel, err := orgParamsWithOrgService.Get(req.OrganizationID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	avg, err := orgParamsWithOrgService.ComputeAverageAcross(paramsModel, req.Params)
+	avg, breakdown, err := orgParamsWithOrgService.ExplainAverage(paramsModel.WithAggregate(req.Aggregate), req.Params, nil)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	resp := OrganizationParamsWithOrgResponse{Params: req.Params, Average: avg}
+	pct, err := percentileService.ForOrganizations([]uint{org.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := OrganizationParamsWithOrgResponse{Params: req.Params, Average: avg, Breakdown: withPercentiles(breakdown, pct[org.ID])}
 	resp.Organization.ID = org.ID
+	resp.Organization.Name = org.Name
+	resp.Organization.Description = org.Description
+	resp.Organization.Phone = org.Phone
+	resp.Organization.Website = org.Website
+	resp.Organization.OpeningHours = org.OpeningHours
+	resp.Organization.QuietHours = org.QuietHours
 	resp.Organization.Address = org.Address
 	resp.Organization.OrganizationType = org.OrganizationType
 	resp.Organization.Longitude = org.Longitude
 	resp.Organization.Latitude = org.Latitude
 	resp.Organization.MapPath = org.MapPath
 	resp.Organization.PicturePath = org.PicturePath
+	resp.Organization.Percentiles = pct[org.ID]
 
 	c.JSON(http.StatusOK, resp)
 }
@@ -3,8 +3,10 @@ package handler
 import (
 	"net/http"
 
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/service"
+
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 // OrganizationByAddressRequest request body for public lookup
@@ -13,25 +15,47 @@ type OrganizationByAddressRequest struct {
 }
 
 type OrganizationByAddressResponse struct {
-	ID               uint     `json:"id"`
-	Address          string   `json:"address"`
-	OrganizationType string   `json:"organization_type"`
-	Longitude        *float64 `json:"longitude"`
-	Latitude         *float64 `json:"latitude"`
-	MapPath          *string  `json:"map_path"`
-	PicturePath      *string  `json:"picture_path"`
+	ID               uint                `json:"id"`
+	Name             string              `json:"name"`
+	Description      string              `json:"description"`
+	Phone            *string             `json:"phone"`
+	Website          *string             `json:"website"`
+	OpeningHours     *model.OpeningHours `json:"opening_hours"`
+	QuietHours       *model.OpeningHours `json:"quiet_hours"`
+	Address          string              `json:"address"`
+	OrganizationType string              `json:"organization_type"`
+	Longitude        *float64            `json:"longitude"`
+	Latitude         *float64            `json:"latitude"`
+	MapPath          *string             `json:"map_path"`
+	PicturePath      *string             `json:"picture_path"`
+	Match            string              `json:"match"` // exact | normalized
+}
+
+type OrganizationAddressCandidate struct {
+	ID               uint    `json:"id"`
+	Name             string  `json:"name"`
+	Address          string  `json:"address"`
+	OrganizationType string  `json:"organization_type"`
+	Similarity       float64 `json:"similarity"`  // 0..1, триграммное сходство нормализованных адресов
+	HouseMatch       bool    `json:"house_match"` // номер дома совпадает с запрошенным
+}
+
+type OrganizationByAddressNotFoundResponse struct {
+	Error      string                         `json:"error"`
+	Candidates []OrganizationAddressCandidate `json:"candidates"`
 }
 
 // GetOrganizationByAddressPublic godoc
 // @Summary Public organization lookup by address
-// @Description Возвращает организацию (id и основные поля) по точному адресу. Публично.
+// @Description Возвращает организацию (id и основные поля) по адресу. Сначала точное совпадение, затем совпадение нормализованного адреса (регистр, пунктуация, сокращения «ул.», «пр-т», «д.», «к.» и т.п.) — поле match = exact | normalized. Если ничего не нашлось, 404 содержит до 5 похожих адресов (триграммное сходство) с оценкой similarity; кандидаты с тем же номером дома идут первыми. Публично.
 // @Tags organization
 // @Accept json
 // @Produce json
 // @Param input body OrganizationByAddressRequest true "Address lookup"
 // @Success 200 {object} OrganizationByAddressResponse
 // @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
+// @Failure 404 {object} OrganizationByAddressNotFoundResponse
+// @Failure 500 {object} map[string]string
 // @Router /organization/public/by-address [post]
 func GetOrganizationByAddressPublic(c *gin.Context) {
 	var req OrganizationByAddressRequest
@@ -39,24 +63,43 @@ func GetOrganizationByAddressPublic(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	org, err := organizationService.GetByAddress(req.Address)
+	matches, err := organizationService.FindByAddress(req.Address)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
-			return
-		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if len(matches) == 0 || matches[0].Match == service.AddressMatchSimilar {
+		resp := OrganizationByAddressNotFoundResponse{Error: "not found", Candidates: make([]OrganizationAddressCandidate, 0, len(matches))}
+		for _, m := range matches {
+			resp.Candidates = append(resp.Candidates, OrganizationAddressCandidate{
+				ID:               m.Organization.ID,
+				Name:             m.Organization.Name,
+				Address:          m.Organization.Address,
+				OrganizationType: m.Organization.OrganizationType,
+				Similarity:       m.Similarity,
+				HouseMatch:       m.HouseMatch,
+			})
+		}
+		c.JSON(http.StatusNotFound, resp)
+		return
+	}
+	org := matches[0].Organization
 
 	resp := OrganizationByAddressResponse{
 		ID:               org.ID,
+		Name:             org.Name,
+		Description:      org.Description,
+		Phone:            org.Phone,
+		Website:          org.Website,
+		OpeningHours:     org.OpeningHours,
+		QuietHours:       org.QuietHours,
 		Address:          org.Address,
 		OrganizationType: org.OrganizationType,
 		Longitude:        org.Longitude,
 		Latitude:         org.Latitude,
 		MapPath:          org.MapPath,
 		PicturePath:      org.PicturePath,
+		Match:            matches[0].Match,
 	}
 	c.JSON(http.StatusOK, resp)
 }
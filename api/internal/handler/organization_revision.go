@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var organizationRevisionService = service.NewOrganizationRevisionService()
+
+type OrganizationHistoryResponse struct {
+	OrganizationID uint                         `json:"organization_id"`
+	Items          []model.OrganizationRevision `json:"items"`
+}
+
+// GetOrganizationHistory godoc
+// @Summary Organization edit history
+// @Description Журнал изменений организации (создание, правки, загрузка изображений, смена статуса, удаление/восстановление, откаты, передача владения по заявке — action=owner, изменение удобств — action=amenities): кто, когда, снимки полей до и после, список изменённых полей. Новые сверху. Владелец организации или admin.
+// @Tags organization
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path int true "Organization ID"
+// @Param limit query int false "Max items (default 50, max 200)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} OrganizationHistoryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organization/{organization_id}/history [get]
+func GetOrganizationHistory(c *gin.Context) {
+	org, _, ok := managedOrganization(c)
+	if !ok {
+		return
+	}
+	limit, offset := 50, 0
+	if v := c.Query("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l < 1 || l > 200 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be 1..200"})
+			return
+		}
+		limit = l
+	}
+	if v := c.Query("offset"); v != "" {
+		o, err := strconv.Atoi(v)
+		if err != nil || o < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid offset"})
+			return
+		}
+		offset = o
+	}
+	list, err := organizationRevisionService.List(org.ID, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, OrganizationHistoryResponse{OrganizationID: org.ID, Items: list})
+}
+
+// RevertOrganizationRevision godoc
+// @Summary Revert organization to a revision
+// @Description Восстанавливает поля организации в состояние после указанной ревизии (кроме принадлежности к бренду — она меняется только через привязку/отвязку бренда); тип организации приводится к текущему справочнику (409, если его там больше нет); сам откат записывается в историю новой ревизией (action=revert). Владелец организации или admin.
+// @Tags organization
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path int true "Organization ID"
+// @Param revision_id path int true "Revision ID"
+// @Success 200 {object} model.Organization
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organization/{organization_id}/history/{revision_id}/revert [post]
+func RevertOrganizationRevision(c *gin.Context) {
+	org, uid, ok := managedOrganization(c)
+	if !ok {
+		return
+	}
+	revID, err := strconv.ParseUint(c.Param("revision_id"), 10, 64)
+	if err != nil || revID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid revision_id"})
+		return
+	}
+	org, err = organizationRevisionService.Revert(org.ID, uint(revID), uid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "revision not found"})
+			return
+		}
+		if errors.Is(err, service.ErrRevisionTypeUnknown) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, org)
+}
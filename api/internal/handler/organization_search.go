@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/repository"
+	"2gis-calm-map/api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+var organizationSearchService = service.NewOrganizationSearchService()
+
+type OrganizationSearchItem struct {
+	Organization model.Organization `json:"organization"`
+	Rank         float64            `json:"rank"`
+	Average      *float64           `json:"average,omitempty"` // среднее по params, если они заданы
+}
+
+type OrganizationSearchResponse struct {
+	Query string                   `json:"query"`
+	Items []OrganizationSearchItem `json:"items"`
+}
+
+// parseMinParams parses "smell:4,lighting:3.5".
+func parseMinParams(v string) (map[string]float64, error) {
+	mins := map[string]float64{}
+	for _, part := range strings.Split(v, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, errors.New("invalid min, expected param:value[,param:value]")
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, errors.New("invalid min value for " + name)
+		}
+		mins[strings.TrimSpace(name)] = f
+	}
+	return mins, nil
+}
+
+// parseFloatQuery reads an optional float query parameter.
+func parseFloatQuery(c *gin.Context, key string) (*float64, error) {
+	v := c.Query(key)
+	if v == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil, errors.New("invalid " + key)
+	}
+	return &f, nil
+}
+
+// SearchOrganizations godoc
+// @Summary Full-text organization search
+// @Description Полнотекстовый поиск по названию, адресу, типу (включая названия из справочника) и описанию с учётом русской морфологии. Последнее слово ищется по префиксу (автодополнение). Результаты отсортированы по релевантности. Фильтры комбинируются: type, min (минимумы по параметрам), lat/lon/radius, open_now/open_at, quiet_now/quiet_at, brand, amenities. Закрытые организации (status != active) исключаются, если не передан include_archived. Публично.
+// @Tags organization
+// @Produce json
+// @Param q query string true "Search text"
+// @Param type query string false "Organization type (slug or name)"
+// @Param params query string false "Comma-separated params; average over them is returned"
+// @Param min query string false "Per-factor minimums, e.g. smell:4,lighting:3.5"
+// @Param aggregate query string false "plain (default) | sensitivity"
+// @Param lat query number false "Latitude of the center (with lon and radius)"
+// @Param lon query number false "Longitude of the center"
+// @Param radius query number false "Radius in meters"
+// @Param open_now query bool false "Only organizations open now"
+// @Param open_at query string false "Only organizations open at this moment (RFC3339)"
+// @Param quiet_now query bool false "Only organizations in quiet hours now"
+// @Param quiet_at query string false "Only organizations in quiet hours at this moment (RFC3339)"
+// @Param include_archived query bool false "Include temporarily/permanently closed organizations"
+// @Param brand query int false "Only branches of this brand (id)"
+// @Param amenities query string false "Comma-separated required amenity slugs (see GET /amenities)"
+// @Param limit query int false "Max items (default 20, max 100)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} OrganizationSearchResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organization/search [get]
+func SearchOrganizations(c *gin.Context) {
+	text := strings.TrimSpace(c.Query("q"))
+	if repository.SearchTsQuery(text) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q is required"})
+		return
+	}
+	q := repository.OrganizationSearchQuery{Text: text, Limit: 20}
+	if !bindSearchFilters(c, &q) {
+		return
+	}
+	if v := c.Query("params"); v != "" {
+		q.Params = strings.Split(v, ",")
+	}
+	if v := c.Query("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l < 1 || l > 100 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be 1..100"})
+			return
+		}
+		q.Limit = l
+	}
+	if v := c.Query("offset"); v != "" {
+		o, err := strconv.Atoi(v)
+		if err != nil || o < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid offset"})
+			return
+		}
+		q.Offset = o
+	}
+
+	rows, err := organizationSearchService.Search(q)
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownParam) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	resp := OrganizationSearchResponse{Query: text, Items: make([]OrganizationSearchItem, 0, len(rows))}
+	for _, r := range rows {
+		item := OrganizationSearchItem{Organization: r.Organization, Rank: r.Rank}
+		if len(q.Params) > 0 {
+			avg := r.Average
+			item.Average = &avg
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// bindSearchFilters reads the filters shared by full-text search and export (type, min, aggregate, lat/lon/radius,
+// open_*/quiet_*, brand, amenities, include_archived) into q. On failure the response is already written.
+func bindSearchFilters(c *gin.Context, q *repository.OrganizationSearchQuery) bool {
+	q.Aggregate = c.Query("aggregate")
+	if !model.IsAggregate(q.Aggregate) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "aggregate must be plain or sensitivity"})
+		return false
+	}
+	if v := c.Query("type"); v != "" {
+		q.OrganizationType = organizationTypeService.ResolveOrRaw(v)
+	}
+	if v := c.Query("min"); v != "" {
+		mins, err := parseMinParams(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return false
+		}
+		q.MinParams = mins
+	}
+	lat, err := parseFloatQuery(c, "lat")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	lon, err := parseFloatQuery(c, "lon")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	radius, err := parseFloatQuery(c, "radius")
+	if err != nil || (radius != nil && *radius <= 0) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid radius"})
+		return false
+	}
+	if radius != nil {
+		if lat == nil || lon == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "radius requires lat and lon"})
+			return false
+		}
+		q.Latitude, q.Longitude, q.Radius = lat, lon, *radius
+	}
+	if q.OpenAt, err = parseOpenQuery(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	if q.QuietAt, err = parseQuietQuery(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	if v := c.Query("brand"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil || id == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid brand"})
+			return false
+		}
+		brandID := uint(id)
+		q.BrandID = &brandID
+	}
+	if v := c.Query("amenities"); v != "" {
+		if q.Amenities, err = amenityService.Normalize(strings.Split(v, ",")); err != nil {
+			if errors.Is(err, service.ErrUnknownAmenity) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return false
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return false
+		}
+	}
+	if v := c.Query("include_archived"); v != "" {
+		if q.IncludeArchived, err = strconv.ParseBool(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid include_archived"})
+			return false
+		}
+	}
+	return true
+}
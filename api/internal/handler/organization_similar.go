@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var similarityService = service.NewOrganizationSimilarityService()
+
+type SimilarOrganizationItem struct {
+	ID               uint                `json:"id"`
+	Name             string              `json:"name"`
+	Description      string              `json:"description"`
+	Phone            *string             `json:"phone"`
+	Website          *string             `json:"website"`
+	OpeningHours     *model.OpeningHours `json:"opening_hours"`
+	QuietHours       *model.OpeningHours `json:"quiet_hours"`
+	Address          string              `json:"address"`
+	OrganizationType string              `json:"organization_type"`
+	Longitude        *float64            `json:"longitude"`
+	Latitude         *float64            `json:"latitude"`
+	Similarity       float64             `json:"similarity"` // 0..1, больше — ближе
+	Distance         float64             `json:"distance"`   // евклидово расстояние между нормализованными векторами
+}
+
+type SimilarOrganizationsResponse struct {
+	OrganizationID uint                      `json:"organization_id"`
+	Metric         string                    `json:"metric"`
+	Items          []SimilarOrganizationItem `json:"items"`
+}
+
+// GetSimilarOrganizations godoc
+// @Summary Similar calm places
+// @Description Ищет организации с похожим профилем оценок (11 параметров, нормализованы в [-1,1], неоценённые = 0). Векторы предрасчитываются при каждом новом отзыве. Публично.
+// @Tags organization-params
+// @Produce json
+// @Param organization_id path int true "Organization ID"
+// @Param metric query string false "euclidean (default) | cosine"
+// @Param same_type query bool false "Only organizations of the same type"
+// @Param radius query number false "Radius in meters around the organization"
+// @Param open_now query bool false "Only organizations open now"
+// @Param open_at query string false "Only organizations open at this moment (RFC3339)"
+// @Param quiet_now query bool false "Only organizations in quiet hours now"
+// @Param quiet_at query string false "Only organizations in quiet hours at this moment (RFC3339)"
+// @Param limit query int false "Max items (default 10, max 50)"
+// @Success 200 {object} SimilarOrganizationsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organization/{organization_id}/similar [get]
+func GetSimilarOrganizations(c *gin.Context) {
+	var orgID uint
+	if _, err := fmt.Sscan(c.Param("organization_id"), &orgID); err != nil || orgID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization_id"})
+		return
+	}
+	opts := service.SimilarOptions{Metric: c.DefaultQuery("metric", "euclidean"), Limit: 10}
+	if opts.Metric != "euclidean" && opts.Metric != "cosine" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "metric must be euclidean or cosine"})
+		return
+	}
+	if v := c.Query("same_type"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid same_type"})
+			return
+		}
+		opts.SameType = b
+	}
+	if v := c.Query("radius"); v != "" {
+		r, err := strconv.ParseFloat(v, 64)
+		if err != nil || r <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid radius"})
+			return
+		}
+		opts.Radius = r
+	}
+	if v := c.Query("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l < 1 || l > 50 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be 1..50"})
+			return
+		}
+		opts.Limit = l
+	}
+
+	openAt, err := parseOpenQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	opts.OpenAt = openAt
+	if opts.QuietAt, err = parseQuietQuery(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	org, err := orgService.GetByID(orgID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "organization not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	rows, err := similarityService.Similar(org, opts)
+	if err != nil {
+		if errors.Is(err, service.ErrNoCoordinates) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	items := make([]SimilarOrganizationItem, 0, len(rows))
+	for _, r := range rows {
+		items = append(items, SimilarOrganizationItem{
+			ID:               r.ID,
+			Name:             r.Name,
+			Description:      r.Description,
+			Phone:            r.Phone,
+			Website:          r.Website,
+			OpeningHours:     r.OpeningHours,
+			QuietHours:       r.QuietHours,
+			Address:          r.Address,
+			OrganizationType: r.OrganizationType,
+			Longitude:        r.Longitude,
+			Latitude:         r.Latitude,
+			Similarity:       r.Similarity,
+			Distance:         r.Distance,
+		})
+	}
+	c.JSON(http.StatusOK, SimilarOrganizationsResponse{OrganizationID: orgID, Metric: opts.Metric, Items: items})
+}
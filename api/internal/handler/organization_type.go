@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+
+	"2gis-calm-map/api/internal/repository"
+	"2gis-calm-map/api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+var organizationTypeService = service.NewOrganizationTypeService()
+
+type OrganizationTypesResponse struct {
+	Items []repository.OrganizationTypeCount `json:"items"`
+}
+
+// GetOrganizationTypes godoc
+// @Summary List organization types
+// @Description Справочник типов организаций: slug (хранится в organization_type), названия на русском и английском, родительская категория и число организаций этого типа. При создании/изменении организации тип можно передать как slug или локализованное название. Публично.
+// @Tags organization
+// @Produce json
+// @Success 200 {object} OrganizationTypesResponse
+// @Failure 500 {object} map[string]string
+// @Router /organization-types [get]
+func GetOrganizationTypes(c *gin.Context) {
+	list, err := organizationTypeService.List()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, OrganizationTypesResponse{Items: list})
+}
@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/repository"
 	"2gis-calm-map/api/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -13,12 +17,36 @@ type OrganizationsParamsAverageByTypeRequest struct {
 	Params           []string `json:"params" binding:"required,min=1"`
 	// Optional threshold; if omitted, defaults to 3.0
 	Threshold *float64 `json:"threshold"`
+	// Optional per-factor minimums (avg >= value), e.g. {"smell": 4, "lighting": 3.5}
+	MinParams map[string]float64 `json:"min_params"`
+	// Optional minimum number of comments for organization
+	MinReviews uint `json:"min_reviews"`
+	// average_desc (default) | average_asc | reviews_desc
+	Sort   string `json:"sort" binding:"omitempty,oneof=average_desc average_asc reviews_desc"`
+	Limit  int    `json:"limit" binding:"omitempty,min=1,max=500"`
+	Offset int    `json:"offset" binding:"omitempty,min=0"`
+	// plain (default) | sensitivity — среднее, где оценки авторов, отметивших параметр важным, весят больше
+	Aggregate string `json:"aggregate" binding:"omitempty,oneof=plain sensitivity"`
+	// Only organizations open now (or at open_at, RFC3339) according to their opening hours
+	OpenNow bool       `json:"open_now"`
+	OpenAt  *time.Time `json:"open_at"`
+	// Only organizations in quiet hours now (or at quiet_at, RFC3339)
+	QuietNow bool       `json:"quiet_now"`
+	QuietAt  *time.Time `json:"quiet_at"`
+	// Include temporarily/permanently closed organizations (excluded by default)
+	IncludeArchived bool `json:"include_archived"`
+	// Only branches of this brand
+	BrandID *uint `json:"brand_id"`
+	// Required amenity slugs (see GET /amenities); disputed declarations do not count
+	Amenities []string `json:"amenities"`
 }
 
 type OrganizationWithSelectedAverage struct {
-	Organization interface{} `json:"organization"`
-	Average      float64     `json:"average"`
-	Params       []string    `json:"params"`
+	Organization interface{}              `json:"organization"`
+	Average      float64                  `json:"average"`
+	Params       []string                 `json:"params"`
+	ReviewCount  int64                    `json:"review_count"`
+	Breakdown    []service.ParamBreakdown `json:"breakdown"`
 }
 
 type OrganizationsParamsAverageByTypeResponse struct {
@@ -33,6 +61,7 @@ var orgParamsAggService = service.NewOrganizationParamsService()
 // GetOrganizationsParamsAverageByType godoc
 // @Summary Compute averages for each organization of given type
 // @Description For every organization of a specified type computes (avg(p1)+...)/N and returns only those with average > threshold (default 3.0).
+// @Description Дополнительно: min_params — минимумы по отдельным параметрам (avg >= value), min_reviews — минимальное число отзывов, sort/limit/offset, open_now/open_at — только открытые сейчас (или в момент open_at) по часам работы, quiet_now/quiet_at — только в «тихие часы», include_archived — включить закрытые организации, brand_id — только филиалы бренда, amenities — только с указанными удобствами (неоспоренными). Фильтрация и сортировка выполняются в SQL.
 // @Tags organization-params
 // @Accept json
 // @Produce json
@@ -51,41 +80,79 @@ func GetOrganizationsParamsAverageByType(c *gin.Context) {
 		return
 	}
 
-	orgs, err := orgService.GetByType(req.OrganizationType)
+	threshold := 3.0
+	if req.Threshold != nil {
+		threshold = *req.Threshold
+	}
+	orgType := organizationTypeService.ResolveOrRaw(req.OrganizationType)
+	amenities, err := amenityService.Normalize(req.Amenities)
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownAmenity) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	matches, err := orgParamsAggService.SearchByType(repository.OrganizationAverageFilter{
+		OrganizationType: orgType,
+		Params:           req.Params,
+		Threshold:        threshold,
+		MinParams:        req.MinParams,
+		MinReviews:       req.MinReviews,
+		Sort:             req.Sort,
+		Limit:            req.Limit,
+		Offset:           req.Offset,
+		Aggregate:        req.Aggregate,
+		OpenAt:           openAtFilter(req.OpenNow, req.OpenAt),
+		QuietAt:          openAtFilter(req.QuietNow, req.QuietAt),
+		IncludeArchived:  req.IncludeArchived,
+		BrandID:          req.BrandID,
+		Amenities:        amenities,
+	})
 	if err != nil {
+		if errors.Is(err, service.ErrUnknownParam) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	items := make([]OrganizationWithSelectedAverage, 0, len(orgs))
-	defaultThreshold := 3.0
-	threshold := defaultThreshold
-	if req.Threshold != nil {
-		threshold = *req.Threshold
+	ids := make([]uint, 0, len(matches))
+	for _, m := range matches {
+		ids = append(ids, m.ID)
 	}
-	for _, org := range orgs {
-		// Ensure params record exists
-		paramsModel, err := orgParamsAggService.GetOrCreate(org.ID)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
+	pct, err := percentileService.ForOrganizations(ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	items := make([]OrganizationWithSelectedAverage, 0, len(matches))
+	for _, m := range matches {
+		m.Organization.Percentiles = pct[m.ID]
+		p := model.OrganizationParams{OrganizationID: m.ID}
+		if m.Params != nil {
+			p = *m.Params
 		}
-		avg, err := orgParamsAggService.ComputeAverageAcross(paramsModel, req.Params)
+		// среднее уже посчитано в SQL, здесь только расшифровка
+		_, breakdown, err := orgParamsAggService.ExplainAverage(p.WithAggregate(req.Aggregate), req.Params, nil)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		if avg > threshold {
-			items = append(items, OrganizationWithSelectedAverage{
-				Organization: org,
-				Average:      avg,
-				Params:       req.Params,
-			})
-		}
+		items = append(items, OrganizationWithSelectedAverage{
+			Organization: m.Organization,
+			Average:      m.Average,
+			Params:       req.Params,
+			ReviewCount:  m.ReviewCount,
+			Breakdown:    withPercentiles(breakdown, pct[m.ID]),
+		})
 	}
 
 	c.JSON(http.StatusOK, OrganizationsParamsAverageByTypeResponse{
-		OrganizationType: req.OrganizationType,
+		OrganizationType: orgType,
 		Items:            items,
 	})
 }
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"2gis-calm-map/api/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetOrganizationQuietHoursCalendar godoc
+// @Summary Quiet hours iCalendar feed
+// @Description Расписание «тихих часов» организации в формате iCalendar (text/calendar) для подписки в календаре: еженедельные события с RRULE, даты-исключения исключены (EXDATE) или заданы отдельными событиями. Публично.
+// @Tags organization
+// @Produce text/calendar
+// @Param organization_id path int true "Organization ID"
+// @Success 200 {string} string "iCalendar"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organization/{organization_id}/quiet-hours.ics [get]
+func GetOrganizationQuietHoursCalendar(c *gin.Context) {
+	orgID, err := strconv.ParseUint(c.Param("organization_id"), 10, 64)
+	if err != nil || orgID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization_id"})
+		return
+	}
+	org, err := organizationService.GetByID(uint(orgID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "organization not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	cal, err := service.QuietHoursCalendar(org, time.Now())
+	if err != nil {
+		if errors.Is(err, service.ErrNoQuietHours) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="quiet-hours-%d.ics"`, org.ID))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(cal))
+}
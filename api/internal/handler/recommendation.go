@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+var recommendationService = service.NewRecommendationService()
+
+type RecommendationItem struct {
+	OrganizationID   uint                `json:"organization_id"`
+	Name             string              `json:"name"`
+	Description      string              `json:"description"`
+	Phone            *string             `json:"phone"`
+	Website          *string             `json:"website"`
+	OpeningHours     *model.OpeningHours `json:"opening_hours"`
+	QuietHours       *model.OpeningHours `json:"quiet_hours"`
+	Address          string              `json:"address"`
+	OrganizationType string              `json:"organization_type"`
+	Longitude        *float64            `json:"longitude"`
+	Latitude         *float64            `json:"latitude"`
+	Score            float64             `json:"score"`
+	Supporters       uint                `json:"supporters"`
+}
+
+type RecommendationsResponse struct {
+	BuiltAt *time.Time           `json:"built_at"` // null — для пользователя ещё нет рекомендаций
+	Items   []RecommendationItem `json:"items"`
+}
+
+// GetMyRecommendations godoc
+// @Summary Recommendations from similar reviewers
+// @Description Организации, которые высоко оценили пользователи с похожими предпочтениями (UserParams) и оценками, и на которые текущий пользователь ещё не оставлял отзыв. Модель пересчитывается периодически в фоне (RECOMMENDATIONS_INTERVAL).
+// @Tags recommendations
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Max items (default 20, max 50)"
+// @Success 200 {object} RecommendationsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me/recommendations [get]
+func GetMyRecommendations(c *gin.Context) {
+	uidRaw, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	limit := 20
+	if v := c.Query("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l < 1 || l > 50 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be 1..50"})
+			return
+		}
+		limit = l
+	}
+
+	list, err := recommendationService.ForUser(uidRaw.(uint), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	resp := RecommendationsResponse{Items: make([]RecommendationItem, 0, len(list))}
+	for _, r := range list {
+		if resp.BuiltAt == nil {
+			builtAt := r.BuiltAt
+			resp.BuiltAt = &builtAt
+		}
+		resp.Items = append(resp.Items, RecommendationItem{
+			OrganizationID:   r.OrganizationID,
+			Name:             r.Organization.Name,
+			Description:      r.Organization.Description,
+			Phone:            r.Organization.Phone,
+			Website:          r.Organization.Website,
+			OpeningHours:     r.Organization.OpeningHours,
+			QuietHours:       r.Organization.QuietHours,
+			Address:          r.Organization.Address,
+			OrganizationType: r.Organization.OrganizationType,
+			Longitude:        r.Organization.Longitude,
+			Latitude:         r.Organization.Latitude,
+			Score:            r.Score,
+			Supporters:       r.Supporters,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
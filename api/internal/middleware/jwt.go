@@ -16,33 +16,56 @@ func JWTAuth() gin.HandlerFunc {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "no bearer token"})
 			return
 		}
-		tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
-		secret := os.Getenv("JWT_SECRET")
-		if secret == "" {
-			secret = "secret"
-		}
-
-		token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-			return []byte(secret), nil
-		})
-		if err != nil || !token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
-			return
-		}
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid claims"})
-			return
-		}
-		userID, ok := claims["user_id"].(float64)
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "user_id missing"})
+		userID, role, errMsg := parseToken(strings.TrimPrefix(authHeader, "Bearer "))
+		if errMsg != "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": errMsg})
 			return
 		}
-		c.Set("user_id", uint(userID))
-		if role, rok := claims["role"].(string); rok {
+		c.Set("user_id", userID)
+		if role != "" {
 			c.Set("role", role)
 		}
 		c.Next()
 	}
 }
+
+// OptionalJWTAuth sets user_id/role when a valid bearer token is present, otherwise lets the request through anonymously.
+func OptionalJWTAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if strings.HasPrefix(authHeader, "Bearer ") {
+			if userID, role, errMsg := parseToken(strings.TrimPrefix(authHeader, "Bearer ")); errMsg == "" {
+				c.Set("user_id", userID)
+				if role != "" {
+					c.Set("role", role)
+				}
+			}
+		}
+		c.Next()
+	}
+}
+
+// parseToken validates JWT and returns user_id and role; errMsg is non-empty on failure.
+func parseToken(tokenStr string) (uint, string, string) {
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		secret = "secret"
+	}
+
+	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	})
+	if err != nil || !token.Valid {
+		return 0, "", "invalid token"
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0, "", "invalid claims"
+	}
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return 0, "", "user_id missing"
+	}
+	role, _ := claims["role"].(string)
+	return uint(userID), role, ""
+}
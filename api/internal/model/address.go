@@ -0,0 +1,120 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+)
+
+// addressAbbreviations expands common Russian address abbreviations (keys after lowercasing, without dots).
+var addressAbbreviations = map[string]string{
+	"ул":    "улица",
+	"пр":    "проспект",
+	"пр-т":  "проспект",
+	"просп": "проспект",
+	"пр-кт": "проспект",
+	"пер":   "переулок",
+	"пл":    "площадь",
+	"наб":   "набережная",
+	"ш":     "шоссе",
+	"б-р":   "бульвар",
+	"бул":   "бульвар",
+	"бр":    "бульвар",
+	"пр-д":  "проезд",
+	"туп":   "тупик",
+	"мкр":   "микрорайон",
+	"мкрн":  "микрорайон",
+	"к":     "корпус",
+	"корп":  "корпус",
+	"стр":   "строение",
+	"лит":   "литера",
+	"г":     "город",
+	"д":     "дом",
+}
+
+// streetTypes are placed right before the street name, so "Ленина ул." and "ул. Ленина" normalize the same way.
+var streetTypes = map[string]bool{
+	"улица": true, "проспект": true, "переулок": true, "площадь": true, "набережная": true, "шоссе": true,
+	"бульвар": true, "проезд": true, "тупик": true, "микрорайон": true,
+}
+
+// dropped words carry no information for matching ("дом 5" == "5", "город Москва" == "Москва").
+var addressFillers = map[string]bool{"дом": true, "город": true}
+
+// NormalizeAddress returns a canonical form for matching: lowercase, "ё" → "е", no punctuation,
+// abbreviations expanded, street type before the street name ("улица ленина 5 корпус 2").
+func NormalizeAddress(raw string) string {
+	normalized, _ := ParseAddress(raw)
+	return normalized
+}
+
+func isNumberToken(tok string) bool { return tok[0] >= '0' && tok[0] <= '9' }
+
+// ParseAddress normalizes an address and extracts its house number: the first token starting with a digit
+// that follows a word, letter/fraction suffix kept ("5а", "12/3"); house is "" if there is none.
+func ParseAddress(raw string) (normalized, house string) {
+	s := strings.ReplaceAll(strings.ToLower(raw), "ё", "е")
+	// точки и запятые — разделители; дефис и слэш внутри токена сохраняем ("пр-т", "12/3")
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '/'
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, tok := range fields {
+		tok = strings.Trim(tok, "-/")
+		if tok == "" {
+			continue
+		}
+		// "5к2" / "5стр1" → "5 корпус 2"
+		if head, suffix, num, ok := splitBuilding(tok); ok {
+			tokens = append(tokens, head, suffix, num)
+			continue
+		}
+		if full, ok := addressAbbreviations[tok]; ok {
+			tok = full
+		}
+		if !addressFillers[tok] {
+			tokens = append(tokens, tok)
+		}
+	}
+
+	out := make([]string, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if streetTypes[tok] {
+			next := i + 1
+			switch {
+			case next < len(tokens) && !isNumberToken(tokens[next]) && !streetTypes[tokens[next]]:
+				// "улица ленина" — уже в нужном порядке
+				out = append(out, tok, tokens[next])
+				i = next
+			case len(out) > 0 && !isNumberToken(out[len(out)-1]):
+				// "ленина улица" → "улица ленина"
+				out = append(out[:len(out)-1], tok, out[len(out)-1])
+			default:
+				out = append(out, tok)
+			}
+			continue
+		}
+		if house == "" && isNumberToken(tok) && len(out) > 0 {
+			house = tok
+		}
+		out = append(out, tok)
+	}
+	return strings.Join(out, " "), house
+}
+
+// splitBuilding splits "5к2" into ("5", "корпус", "2").
+func splitBuilding(tok string) (head, suffix, num string, ok bool) {
+	for _, abbr := range []string{"корп", "стр", "к"} {
+		i := strings.Index(tok, abbr)
+		if i <= 0 || tok[0] < '0' || tok[0] > '9' {
+			continue
+		}
+		h, n := tok[:i], tok[i+len(abbr):]
+		if n == "" || n[0] < '0' || n[0] > '9' {
+			continue
+		}
+		return h, addressAbbreviations[abbr], n, true
+	}
+	return "", "", "", false
+}
@@ -0,0 +1,52 @@
+package model
+
+import "testing"
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		raw        string
+		normalized string
+		house      string
+	}{
+		{"ул. Ленина, 5", "улица ленина 5", "5"},
+		{"улица Ленина 5", "улица ленина 5", "5"},
+		{"Ленина ул., д. 5", "улица ленина 5", "5"},
+		{"г. Москва, ул. Ленина, дом 5", "москва улица ленина 5", "5"},
+		{"пр-т Мира, 12/3", "проспект мира 12/3", "12/3"},
+		{"просп. Мира 12/3", "проспект мира 12/3", "12/3"},
+		{"Невский пр., 5а", "проспект невский 5а", "5а"},
+		{"ул. Ленина, 5к2", "улица ленина 5 корпус 2", "5"},
+		{"ул. Ленина, 5, корп. 2", "улица ленина 5 корпус 2", "5"},
+		{"ул. Ленина 5 стр 1", "улица ленина 5 строение 1", "5"},
+		{"Пушкинская наб., 1", "набережная пушкинская 1", "1"},
+		{"ул. Зелёная, 7", "улица зеленая 7", "7"},
+		{"ул. Ленина", "улица ленина", ""},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			normalized, house := ParseAddress(tt.raw)
+			if normalized != tt.normalized || house != tt.house {
+				t.Errorf("ParseAddress(%q) = %q, %q; want %q, %q", tt.raw, normalized, house, tt.normalized, tt.house)
+			}
+			if got := NormalizeAddress(tt.raw); got != tt.normalized {
+				t.Errorf("NormalizeAddress(%q) = %q, want %q", tt.raw, got, tt.normalized)
+			}
+		})
+	}
+}
+
+func TestNormalizeAddressEquivalent(t *testing.T) {
+	pairs := [][2]string{
+		{"ул. Ленина, 5", "улица Ленина 5"},
+		{"Ленина ул. 5", "ул Ленина д 5"},
+		{"пр-кт Мира, 12/3", "проспект Мира 12/3"},
+		{"ул. Ленина, 5 к. 2", "Ленина улица 5к2"},
+		{"ул. Ёлочная, 3", "улица елочная 3"},
+	}
+	for _, p := range pairs {
+		if a, b := NormalizeAddress(p[0]), NormalizeAddress(p[1]); a != b {
+			t.Errorf("NormalizeAddress(%q) = %q, NormalizeAddress(%q) = %q; want equal", p[0], a, p[1], b)
+		}
+	}
+}
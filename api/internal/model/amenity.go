@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+// Amenity is an entry of the curated catalog of sensory accommodations.
+type Amenity struct {
+	Slug   string `json:"slug" gorm:"primaryKey"`
+	NameRu string `json:"name_ru"`
+	NameEn string `json:"name_en"`
+}
+
+// DefaultAmenities is seeded on startup (existing rows are left untouched).
+var DefaultAmenities = []Amenity{
+	{Slug: "quiet_room", NameRu: "Тихая комната", NameEn: "Quiet room"},
+	{Slug: "dimmable_lights", NameRu: "Регулируемое освещение", NameEn: "Dimmable lights"},
+	{Slug: "noise_cancelling_headphones", NameRu: "Шумоподавляющие наушники", NameEn: "Noise-cancelling headphones available"},
+	{Slug: "sensory_friendly_hours", NameRu: "Сенсорно-дружественные часы", NameEn: "Sensory-friendly hours"},
+	{Slug: "no_background_music", NameRu: "Без фоновой музыки", NameEn: "No background music"},
+	{Slug: "scent_free", NameRu: "Без резких запахов", NameEn: "Scent-free"},
+	{Slug: "staff_sensory_training", NameRu: "Персонал обучен работе с сенсорными особенностями", NameEn: "Staff trained in sensory needs"},
+	{Slug: "visual_guides", NameRu: "Визуальные подсказки и схемы", NameEn: "Visual guides and schedules"},
+}
+
+// OrganizationAmenity is an amenity declared by the organization owner.
+// Confirmations/Disputes are counters of AmenityVote, kept in sync by the service.
+type OrganizationAmenity struct {
+	ID             uint         `json:"-" gorm:"primaryKey"`
+	OrganizationID uint         `json:"organization_id" gorm:"uniqueIndex:idx_org_amenity"`
+	Organization   Organization `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	AmenitySlug    string       `json:"amenity" gorm:"uniqueIndex:idx_org_amenity;index"`
+	Amenity        Amenity      `json:"-" gorm:"foreignKey:AmenitySlug;references:Slug;constraint:OnDelete:CASCADE"`
+	DeclaredBy     uint         `json:"declared_by"`
+	Confirmations  uint         `json:"confirmations"`
+	Disputes       uint         `json:"disputes"`
+	CreatedAt      time.Time    `json:"created_at"`
+}
+
+// Disputed reports whether reviewers dispute the declaration more often than they confirm it.
+func (a OrganizationAmenity) Disputed() bool { return a.Disputes > a.Confirmations }
+
+// AmenityVote is a reviewer's confirmation (Confirmed = true) or dispute of a declared amenity; one per user.
+type AmenityVote struct {
+	ID             uint         `json:"id" gorm:"primaryKey"`
+	OrganizationID uint         `json:"organization_id" gorm:"uniqueIndex:idx_amenity_vote"`
+	Organization   Organization `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	AmenitySlug    string       `json:"amenity" gorm:"uniqueIndex:idx_amenity_vote"`
+	UserID         uint         `json:"user_id" gorm:"uniqueIndex:idx_amenity_vote"`
+	User           User         `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Confirmed      bool         `json:"confirmed"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
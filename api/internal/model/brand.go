@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// Brand groups branches of a chain; its owner manages every organization of the brand.
+type Brand struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Name        string    `json:"name" gorm:"index:idx_brand_name"`
+	Description string    `json:"description"`
+	Website     *string   `json:"website"`
+	OwnerID     uint      `json:"owner_id" gorm:"index"`
+	Owner       User      `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	CreatedAt   time.Time `json:"created_at"`
+}
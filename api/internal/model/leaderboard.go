@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// LeaderboardEntry is one precomputed place in a top-N list for (type, area, preset).
+// Area is empty for the whole catalog; district-level boards can reuse the same table later.
+type LeaderboardEntry struct {
+	ID               uint         `json:"id" gorm:"primaryKey"`
+	OrganizationType string       `json:"organization_type" gorm:"index:idx_leaderboard_lookup,priority:1"`
+	Area             string       `json:"area" gorm:"index:idx_leaderboard_lookup,priority:2"`
+	Preset           string       `json:"preset" gorm:"index:idx_leaderboard_lookup,priority:3"`
+	Rank             uint         `json:"rank" gorm:"index:idx_leaderboard_lookup,priority:4"`
+	OrganizationID   uint         `json:"organization_id"`
+	Organization     Organization `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Average          float64      `json:"average"`
+	ReviewCount      int64        `json:"review_count"`
+	BuiltAt          time.Time    `json:"built_at"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// Notification kinds.
+const (
+	NotificationClaimSubmitted          = "claim_submitted"
+	NotificationClaimApproved           = "claim_approved"
+	NotificationClaimRejected           = "claim_rejected"
+	NotificationOrganizationTransferred = "organization_transferred"
+	NotificationCommentResponse         = "comment_response"
+)
+
+// Notification is an in-app message for a user.
+type Notification struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	UserID         uint       `json:"user_id" gorm:"index:idx_notification_user_read,priority:1"`
+	User           User       `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Kind           string     `json:"kind"`
+	Message        string     `json:"message"`
+	OrganizationID *uint      `json:"organization_id"`
+	ClaimID        *uint      `json:"claim_id"`
+	CommentID      *uint      `json:"comment_id"`
+	ReadAt         *time.Time `json:"read_at" gorm:"index:idx_notification_user_read,priority:2"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultTimezone is used for opening hours without explicit timezone.
+const DefaultTimezone = "Europe/Moscow"
+
+// OpeningHours is a weekly schedule with date exceptions (holidays, short days), stored as jsonb.
+// Times are local "HH:MM" in Timezone; closes <= opens means the interval runs past midnight
+// ("00:00"-"00:00" — круглосуточно). Closes may be "24:00".
+type OpeningHours struct {
+	Timezone   string             `json:"timezone,omitempty"` // IANA, по умолчанию DefaultTimezone
+	Weekly     []WeeklyOpening    `json:"weekly"`
+	Exceptions []OpeningException `json:"exceptions,omitempty"`
+}
+
+// WeeklyOpening is an interval on an ISO weekday (1 = Monday … 7 = Sunday).
+type WeeklyOpening struct {
+	Day    int    `json:"day"`
+	Opens  string `json:"opens"`
+	Closes string `json:"closes"`
+}
+
+// OpeningException replaces the weekly schedule for a single date ("2006-01-02").
+// Closed = true or empty Intervals means the organization does not work that day.
+type OpeningException struct {
+	Date      string         `json:"date"`
+	Closed    bool           `json:"closed"`
+	Intervals []TimeInterval `json:"intervals,omitempty"`
+	Note      string         `json:"note,omitempty"`
+}
+
+type TimeInterval struct {
+	Opens  string `json:"opens"`
+	Closes string `json:"closes"`
+}
+
+// Value stores hours as jsonb (works for struct saves and map updates alike).
+func (h OpeningHours) Value() (driver.Value, error) {
+	b, err := json.Marshal(h)
+	return string(b), err
+}
+
+func (h *OpeningHours) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, h)
+	case string:
+		return json.Unmarshal([]byte(v), h)
+	}
+	return fmt.Errorf("unsupported opening_hours value: %T", src)
+}
+
+func validClock(v string, allow24 bool) bool {
+	if allow24 && v == "24:00" {
+		return true
+	}
+	_, err := time.Parse("15:04", v)
+	return err == nil && len(v) == 5
+}
+
+func validInterval(opens, closes string) error {
+	if !validClock(opens, false) || !validClock(closes, true) {
+		return fmt.Errorf("invalid interval %q-%q: expected HH:MM", opens, closes)
+	}
+	return nil
+}
+
+// Validate checks days, clock values, dates and timezone.
+func (h OpeningHours) Validate() error {
+	if h.Timezone != "" {
+		if _, err := time.LoadLocation(h.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone: %s", h.Timezone)
+		}
+	}
+	for _, w := range h.Weekly {
+		if w.Day < 1 || w.Day > 7 {
+			return errors.New("weekday must be 1 (Monday) .. 7 (Sunday)")
+		}
+		if err := validInterval(w.Opens, w.Closes); err != nil {
+			return err
+		}
+	}
+	for _, e := range h.Exceptions {
+		if _, err := time.Parse("2006-01-02", e.Date); err != nil {
+			return fmt.Errorf("invalid exception date: %s", e.Date)
+		}
+		for _, iv := range e.Intervals {
+			if err := validInterval(iv.Opens, iv.Closes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
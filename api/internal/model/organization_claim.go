@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// Claim statuses.
+const (
+	ClaimStatusPending  = "pending"
+	ClaimStatusApproved = "approved"
+	ClaimStatusRejected = "rejected"
+)
+
+// OrganizationClaim is a request of an owner to take control of an existing organization.
+// On approval OwnerID of the organization is transferred to ClaimantID.
+type OrganizationClaim struct {
+	ID              uint                     `json:"id" gorm:"primaryKey"`
+	OrganizationID  uint                     `json:"organization_id" gorm:"index:idx_claim_org_status,priority:1"`
+	Organization    Organization             `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	ClaimantID      uint                     `json:"claimant_id" gorm:"index"`
+	Claimant        User                     `json:"-" gorm:"foreignKey:ClaimantID;constraint:OnDelete:CASCADE"`
+	Evidence        string                   `json:"evidence"` // чем заявитель подтверждает право на организацию
+	Contact         string                   `json:"contact"`
+	Status          string                   `json:"status" gorm:"index:idx_claim_org_status,priority:2"`
+	ReviewerID      *uint                    `json:"reviewer_id"`
+	ReviewNote      string                   `json:"review_note"`
+	PreviousOwnerID *uint                    `json:"previous_owner_id"` // владелец до одобрения
+	CreatedAt       time.Time                `json:"created_at"`
+	ReviewedAt      *time.Time               `json:"reviewed_at"`
+	History         []OrganizationClaimEvent `json:"history" gorm:"foreignKey:ClaimID"`
+}
+
+// OrganizationClaimEvent is a status change of a claim (history).
+type OrganizationClaimEvent struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	ClaimID   uint      `json:"claim_id" gorm:"index"`
+	Status    string    `json:"status"`
+	ActorID   uint      `json:"actor_id"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,41 @@
+package model
+
+// Param returns value and per-param comment by canonical name.
+func (c OrganizationComment) Param(name string) (value *uint, comment *string, ok bool) {
+	switch name {
+	case "appearance":
+		return c.AppearanceValue, c.AppearanceComment, true
+	case "lighting":
+		return c.LightingValue, c.LightingComment, true
+	case "smell":
+		return c.SmellValue, c.SmellComment, true
+	case "temperature":
+		return c.TemperatureValue, c.TemperatureComment, true
+	case "tactility":
+		return c.TactilityValue, c.TactilityComment, true
+	case "signage":
+		return c.SignageValue, c.SignageComment, true
+	case "intuitiveness":
+		return c.IntuitivenessValue, c.IntuitivenessComment, true
+	case "staff_attitude":
+		return c.StaffAttitudeValue, c.StaffAttitudeComment, true
+	case "people_density":
+		return c.PeopleDensityValue, c.PeopleDensityComment, true
+	case "self_service":
+		return c.SelfServiceValue, c.SelfServiceComment, true
+	case "calmness":
+		return c.CalmnessValue, c.CalmnessComment, true
+	}
+	return nil, nil, false
+}
+
+// RatedParams returns canonical names of params with a non-zero value in this comment.
+func (c OrganizationComment) RatedParams() []string {
+	var out []string
+	for _, name := range ParamNames {
+		if v, _, _ := c.Param(name); v != nil && *v > 0 {
+			out = append(out, name)
+		}
+	}
+	return out
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// OrganizationCommentResponse is the public reply of the organization (owner or admin) to a review; one per review.
+type OrganizationCommentResponse struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	CommentID      uint      `json:"comment_id" gorm:"uniqueIndex"`
+	OrganizationID uint      `json:"organization_id" gorm:"index"`
+	AuthorID       uint      `json:"author_id"` // кто ответил: владелец организации или admin
+	Text           string    `json:"text"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
@@ -0,0 +1,106 @@
+package model
+
+import "strings"
+
+// ParamNames lists canonical names of the rated parameters.
+// Each name is also the column prefix in organization_params (<name>_avg, <name>_count, <name>_sum).
+var ParamNames = []string{
+	"appearance",
+	"lighting",
+	"smell",
+	"temperature",
+	"tactility",
+	"signage",
+	"intuitiveness",
+	"staff_attitude",
+	"people_density",
+	"self_service",
+	"calmness",
+}
+
+// NormalizeParamName maps user input ("StaffAttitude", "staffattitude", "staff_attitude") to the canonical name.
+func NormalizeParamName(raw string) (string, bool) {
+	name := strings.ToLower(strings.TrimSpace(raw))
+	switch name {
+	case "staffattitude":
+		name = "staff_attitude"
+	case "peopledensity":
+		name = "people_density"
+	case "selfservice":
+		name = "self_service"
+	}
+	for _, n := range ParamNames {
+		if n == name {
+			return n, true
+		}
+	}
+	return "", false
+}
+
+// Factor returns average and count of a single parameter by canonical name.
+func (p OrganizationParams) Factor(name string) (avg float64, count uint, ok bool) {
+	switch name {
+	case "appearance":
+		return p.AppearanceAvg, p.AppearanceCount, true
+	case "lighting":
+		return p.LightingAvg, p.LightingCount, true
+	case "smell":
+		return p.SmellAvg, p.SmellCount, true
+	case "temperature":
+		return p.TemperatureAvg, p.TemperatureCount, true
+	case "tactility":
+		return p.TactilityAvg, p.TactilityCount, true
+	case "signage":
+		return p.SignageAvg, p.SignageCount, true
+	case "intuitiveness":
+		return p.IntuitivenessAvg, p.IntuitivenessCount, true
+	case "staff_attitude":
+		return p.StaffAttitudeAvg, p.StaffAttitudeCount, true
+	case "people_density":
+		return p.PeopleDensityAvg, p.PeopleDensityCount, true
+	case "self_service":
+		return p.SelfServiceAvg, p.SelfServiceCount, true
+	case "calmness":
+		return p.CalmnessAvg, p.CalmnessCount, true
+	}
+	return 0, 0, false
+}
+
+// Aggregate kinds selectable in average endpoints.
+const (
+	AggregatePlain       = "plain"       // обычное среднее
+	AggregateSensitivity = "sensitivity" // среднее, взвешенное по чувствительности авторов
+)
+
+// IsAggregate reports whether kind is a known aggregate ("" is treated as plain).
+func IsAggregate(kind string) bool {
+	return kind == "" || kind == AggregatePlain || kind == AggregateSensitivity
+}
+
+// WithAggregate returns a copy whose *Avg fields hold the requested aggregate,
+// so Factor, averages and vectors work unchanged for either kind.
+func (p OrganizationParams) WithAggregate(kind string) OrganizationParams {
+	if kind != AggregateSensitivity {
+		return p
+	}
+	p.AppearanceAvg = p.AppearanceSensitiveAvg
+	p.LightingAvg = p.LightingSensitiveAvg
+	p.SmellAvg = p.SmellSensitiveAvg
+	p.TemperatureAvg = p.TemperatureSensitiveAvg
+	p.TactilityAvg = p.TactilitySensitiveAvg
+	p.SignageAvg = p.SignageSensitiveAvg
+	p.IntuitivenessAvg = p.IntuitivenessSensitiveAvg
+	p.StaffAttitudeAvg = p.StaffAttitudeSensitiveAvg
+	p.PeopleDensityAvg = p.PeopleDensitySensitiveAvg
+	p.SelfServiceAvg = p.SelfServiceSensitiveAvg
+	p.CalmnessAvg = p.CalmnessSensitiveAvg
+	return p
+}
+
+// AggregateColumnSuffix returns organization_params column suffix with averages of the given kind.
+func AggregateColumnSuffix(kind string) string {
+	if kind == AggregateSensitivity {
+		return "_sensitive_avg"
+	}
+	return "_avg"
+}
@@ -0,0 +1,11 @@
+package model
+
+// OrganizationPercentile stores percentile (0..100) of an organization's param average among organizations of the same type.
+// Computed as cume_dist: доля организаций типа с таким же или более низким средним.
+type OrganizationPercentile struct {
+	OrganizationID uint         `json:"organization_id" gorm:"primaryKey;autoIncrement:false"`
+	Organization   Organization `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Param          string       `json:"param" gorm:"primaryKey;size:32"`
+	Percentile     float64      `json:"percentile"`
+	PeerCount      uint         `json:"peer_count"` // сколько организаций типа имеют оценки по параметру
+}
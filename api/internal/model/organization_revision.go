@@ -0,0 +1,188 @@
+package model
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Revision actions.
+const (
+	RevisionActionCreate    = "create"
+	RevisionActionUpdate    = "update"
+	RevisionActionMedia     = "media"
+	RevisionActionStatus    = "status"
+	RevisionActionDelete    = "delete"
+	RevisionActionRestore   = "restore"
+	RevisionActionRevert    = "revert"
+	RevisionActionOwner     = "owner"     // передача владения по одобренной заявке
+	RevisionActionAmenities = "amenities" // изменение объявленных удобств
+)
+
+// OrganizationRevision is one change of an organization: who, when and full before/after snapshots.
+type OrganizationRevision struct {
+	ID             uint                  `json:"id" gorm:"primaryKey"`
+	OrganizationID uint                  `json:"organization_id" gorm:"index:idx_org_revision_org,priority:1"`
+	Organization   Organization          `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	ActorID        uint                  `json:"actor_id"`
+	Action         string                `json:"action"`
+	Before         *OrganizationSnapshot `json:"before" gorm:"type:jsonb"` // nil для create
+	After          *OrganizationSnapshot `json:"after" gorm:"type:jsonb"`
+	RevertedTo     *uint                 `json:"reverted_to,omitempty"` // для revert: ревизия, состояние после которой восстановлено
+	CreatedAt      time.Time             `json:"created_at" gorm:"index:idx_org_revision_org,priority:2"`
+	// Changed: имена полей снимка, отличающихся в Before и After; заполняется сервисом
+	Changed []string `json:"changed" gorm:"-"`
+}
+
+// OrganizationSnapshot holds the user-editable fields of an organization (json names match Organization).
+type OrganizationSnapshot struct {
+	Name             string        `json:"name"`
+	Description      string        `json:"description"`
+	Phone            *string       `json:"phone"`
+	Website          *string       `json:"website"`
+	OpeningHours     *OpeningHours `json:"opening_hours"`
+	QuietHours       *OpeningHours `json:"quiet_hours"`
+	Address          string        `json:"address"`
+	Longitude        *float64      `json:"longitude"`
+	Latitude         *float64      `json:"latitude"`
+	OrganizationType string        `json:"organization_type"`
+	BrandID          *uint         `json:"brand_id"`
+	MapPath          *string       `json:"map_path"`
+	PicturePath      *string       `json:"picture_path"`
+	Status           string        `json:"status"`
+	OwnerID          uint          `json:"owner_id"`
+	// Amenities: объявленные удобства (slug); заполняется только в ревизиях action=amenities
+	Amenities *[]string `json:"amenities,omitempty"`
+	// present: json-ключи, которые были в сохранённом снимке (nil — все поля, снимок построен SnapshotOf).
+	// Снимки, записанные до появления поля, его не содержат — при откате такие колонки не трогаем.
+	present map[string]bool
+}
+
+// UnmarshalJSON also remembers which fields the stored snapshot contains (see Updates).
+func (s *OrganizationSnapshot) UnmarshalJSON(data []byte) error {
+	type plain OrganizationSnapshot
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	s.present = make(map[string]bool, len(keys))
+	for k := range keys {
+		s.present[k] = true
+	}
+	return nil
+}
+
+func SnapshotOf(org Organization) *OrganizationSnapshot {
+	return &OrganizationSnapshot{
+		Name:             org.Name,
+		Description:      org.Description,
+		Phone:            org.Phone,
+		Website:          org.Website,
+		OpeningHours:     org.OpeningHours,
+		QuietHours:       org.QuietHours,
+		Address:          org.Address,
+		Longitude:        org.Longitude,
+		Latitude:         org.Latitude,
+		OrganizationType: org.OrganizationType,
+		BrandID:          org.BrandID,
+		MapPath:          org.MapPath,
+		PicturePath:      org.PicturePath,
+		Status:           org.Status,
+		OwnerID:          org.OwnerID,
+	}
+}
+
+// Updates returns column updates restoring the snapshot (nil pointers reset columns to NULL).
+// Brand membership, ownership and amenities are not restored: they change only through their own authorized flows
+// (brand attach, ownership claims, amenity declarations).
+// Fields missing from a stored snapshot (recorded before they existed) are left as they are.
+func (s OrganizationSnapshot) Updates() map[string]interface{} {
+	updates := map[string]interface{}{
+		"name":               s.Name,
+		"description":        s.Description,
+		"phone":              s.Phone,
+		"website":            s.Website,
+		"opening_hours":      nil,
+		"quiet_hours":        nil,
+		"address":            s.Address,
+		"address_normalized": NormalizeAddress(s.Address),
+		"longitude":          s.Longitude,
+		"latitude":           s.Latitude,
+		"organization_type":  s.OrganizationType,
+		"map_path":           s.MapPath,
+		"picture_path":       s.PicturePath,
+		"status":             s.Status,
+	}
+	if s.OpeningHours != nil {
+		updates["opening_hours"] = *s.OpeningHours
+	}
+	if s.QuietHours != nil {
+		updates["quiet_hours"] = *s.QuietHours
+	}
+	if s.Status == "" {
+		updates["status"] = OrganizationStatusActive
+	}
+	if s.present != nil {
+		for column := range updates {
+			key := column
+			if column == "address_normalized" {
+				key = "address"
+			}
+			if !s.present[key] {
+				delete(updates, column)
+			}
+		}
+	}
+	return updates
+}
+
+// ChangedFields compares snapshots field by field (by json value); nil before means every non-empty field changed.
+func ChangedFields(before, after *OrganizationSnapshot) []string {
+	fields := func(s *OrganizationSnapshot) map[string]json.RawMessage {
+		m := map[string]json.RawMessage{}
+		if s != nil {
+			b, _ := json.Marshal(s)
+			_ = json.Unmarshal(b, &m)
+		}
+		return m
+	}
+	b, a := fields(before), fields(after)
+	changed := []string{}
+	for name, v := range a {
+		old, ok := b[name]
+		if !ok {
+			if !bytes.Equal(v, []byte("null")) && !bytes.Equal(v, []byte(`""`)) {
+				changed = append(changed, name)
+			}
+			continue
+		}
+		if !bytes.Equal(old, v) {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func (s OrganizationSnapshot) Value() (driver.Value, error) {
+	b, err := json.Marshal(s)
+	return string(b), err
+}
+
+func (s *OrganizationSnapshot) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	}
+	return fmt.Errorf("unsupported organization snapshot value: %T", src)
+}
@@ -0,0 +1,17 @@
+package model
+
+// Organization statuses: only active organizations take part in search, rankings and recommendations.
+// Archived (temporarily/permanently closed) organizations keep their reviews and aggregates.
+const (
+	OrganizationStatusActive            = "active"
+	OrganizationStatusTemporarilyClosed = "temporarily_closed"
+	OrganizationStatusClosedPermanently = "closed_permanently"
+)
+
+func ValidOrganizationStatus(s string) bool {
+	switch s {
+	case OrganizationStatusActive, OrganizationStatusTemporarilyClosed, OrganizationStatusClosedPermanently:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package model
+
+import "strings"
+
+// OrganizationType is an entry of the managed type taxonomy; organizations.organization_type stores Slug.
+type OrganizationType struct {
+	Slug       string  `json:"slug" gorm:"primaryKey"`
+	NameRu     string  `json:"name_ru"`
+	NameEn     string  `json:"name_en"`
+	ParentSlug *string `json:"parent_slug" gorm:"index:idx_org_type_parent"` // категория верхнего уровня, nil — сама категория
+}
+
+func category(slug string) *string { return &slug }
+
+// DefaultOrganizationTypes is seeded on startup (existing rows are left untouched).
+var DefaultOrganizationTypes = []OrganizationType{
+	{Slug: "food", NameRu: "Еда и напитки", NameEn: "Food & drinks"},
+	{Slug: "cafe", NameRu: "Кафе", NameEn: "Cafe", ParentSlug: category("food")},
+	{Slug: "coffee_shop", NameRu: "Кофейня", NameEn: "Coffee shop", ParentSlug: category("food")},
+	{Slug: "restaurant", NameRu: "Ресторан", NameEn: "Restaurant", ParentSlug: category("food")},
+	{Slug: "fast_food", NameRu: "Фастфуд", NameEn: "Fast food", ParentSlug: category("food")},
+
+	{Slug: "shopping", NameRu: "Магазины", NameEn: "Shopping"},
+	{Slug: "supermarket", NameRu: "Супермаркет", NameEn: "Supermarket", ParentSlug: category("shopping")},
+	{Slug: "shop", NameRu: "Магазин", NameEn: "Shop", ParentSlug: category("shopping")},
+	{Slug: "mall", NameRu: "Торговый центр", NameEn: "Shopping mall", ParentSlug: category("shopping")},
+	{Slug: "pharmacy", NameRu: "Аптека", NameEn: "Pharmacy", ParentSlug: category("shopping")},
+
+	{Slug: "health", NameRu: "Здоровье", NameEn: "Health"},
+	{Slug: "clinic", NameRu: "Поликлиника", NameEn: "Clinic", ParentSlug: category("health")},
+	{Slug: "hospital", NameRu: "Больница", NameEn: "Hospital", ParentSlug: category("health")},
+	{Slug: "dentist", NameRu: "Стоматология", NameEn: "Dentist", ParentSlug: category("health")},
+
+	{Slug: "culture", NameRu: "Культура и досуг", NameEn: "Culture & leisure"},
+	{Slug: "museum", NameRu: "Музей", NameEn: "Museum", ParentSlug: category("culture")},
+	{Slug: "library", NameRu: "Библиотека", NameEn: "Library", ParentSlug: category("culture")},
+	{Slug: "cinema", NameRu: "Кинотеатр", NameEn: "Cinema", ParentSlug: category("culture")},
+	{Slug: "theatre", NameRu: "Театр", NameEn: "Theatre", ParentSlug: category("culture")},
+	{Slug: "park", NameRu: "Парк", NameEn: "Park", ParentSlug: category("culture")},
+	{Slug: "fitness", NameRu: "Фитнес", NameEn: "Fitness", ParentSlug: category("culture")},
+
+	{Slug: "services", NameRu: "Услуги", NameEn: "Services"},
+	{Slug: "bank", NameRu: "Банк", NameEn: "Bank", ParentSlug: category("services")},
+	{Slug: "post_office", NameRu: "Почта", NameEn: "Post office", ParentSlug: category("services")},
+	{Slug: "government", NameRu: "Госучреждение", NameEn: "Government office", ParentSlug: category("services")},
+	{Slug: "hotel", NameRu: "Гостиница", NameEn: "Hotel", ParentSlug: category("services")},
+
+	{Slug: "education", NameRu: "Образование", NameEn: "Education"},
+	{Slug: "school", NameRu: "Школа", NameEn: "School", ParentSlug: category("education")},
+	{Slug: "kindergarten", NameRu: "Детский сад", NameEn: "Kindergarten", ParentSlug: category("education")},
+	{Slug: "university", NameRu: "Университет", NameEn: "University", ParentSlug: category("education")},
+}
+
+var cyrillicToLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z", 'и': "i",
+	'й': "i", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t",
+	'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "",
+	'э': "e", 'ю': "iu", 'я': "ia",
+}
+
+// SlugifyOrganizationType turns free-form type ("Кофейня", " Coffee Shop ") into a slug ("kofeinia", "coffee_shop").
+func SlugifyOrganizationType(raw string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(strings.TrimSpace(raw)) {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			b.WriteRune(r)
+			underscore = false
+		case cyrillicToLatin[r] != "" || r == 'ъ' || r == 'ь':
+			b.WriteString(cyrillicToLatin[r])
+			underscore = false
+		default:
+			if !underscore && b.Len() > 0 {
+				b.WriteByte('_')
+				underscore = true
+			}
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
@@ -0,0 +1,64 @@
+package model
+
+import "math"
+
+// OrganizationVector is a precomputed normalized vector of per-param averages used for similarity search.
+// Each component is (avg-3)/2 in [-1, 1]; unrated params are 0 (neutral). Refreshed on every aggregate change.
+type OrganizationVector struct {
+	OrganizationID uint         `json:"organization_id" gorm:"primaryKey;autoIncrement:false"`
+	Organization   Organization `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+
+	Appearance    float64 `json:"appearance"`
+	Lighting      float64 `json:"lighting"`
+	Smell         float64 `json:"smell"`
+	Temperature   float64 `json:"temperature"`
+	Tactility     float64 `json:"tactility"`
+	Signage       float64 `json:"signage"`
+	Intuitiveness float64 `json:"intuitiveness"`
+	StaffAttitude float64 `json:"staff_attitude"`
+	PeopleDensity float64 `json:"people_density"`
+	SelfService   float64 `json:"self_service"`
+	Calmness      float64 `json:"calmness"`
+
+	Norm       float64 `json:"norm"`        // евклидова длина вектора (для косинусной близости)
+	RatedCount uint    `json:"rated_count"` // сколько параметров имеют оценки
+}
+
+// VectorFromParams builds normalized vector from aggregates.
+func VectorFromParams(p OrganizationParams) OrganizationVector {
+	vals := make([]float64, len(ParamNames))
+	var rated uint
+	var sq float64
+	for i, name := range ParamNames {
+		avg, _, _ := p.Factor(name)
+		if avg > 0 {
+			vals[i] = (avg - 3) / 2
+			sq += vals[i] * vals[i]
+			rated++
+		}
+	}
+	return OrganizationVector{
+		OrganizationID: p.OrganizationID,
+		Appearance:     vals[0],
+		Lighting:       vals[1],
+		Smell:          vals[2],
+		Temperature:    vals[3],
+		Tactility:      vals[4],
+		Signage:        vals[5],
+		Intuitiveness:  vals[6],
+		StaffAttitude:  vals[7],
+		PeopleDensity:  vals[8],
+		SelfService:    vals[9],
+		Calmness:       vals[10],
+		Norm:           math.Sqrt(sq),
+		RatedCount:     rated,
+	}
+}
+
+// Values returns components in model.ParamNames order (column names are the same as param names).
+func (v OrganizationVector) Values() []float64 {
+	return []float64{
+		v.Appearance, v.Lighting, v.Smell, v.Temperature, v.Tactility, v.Signage,
+		v.Intuitiveness, v.StaffAttitude, v.PeopleDensity, v.SelfService, v.Calmness,
+	}
+}
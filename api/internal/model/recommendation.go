@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// UserRecommendation is a precomputed (offline) collaborative-filtering suggestion for a user.
+type UserRecommendation struct {
+	ID             uint         `json:"id" gorm:"primaryKey"`
+	UserID         uint         `json:"user_id" gorm:"index"`
+	User           User         `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	OrganizationID uint         `json:"organization_id"`
+	Organization   Organization `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Score          float64      `json:"score"`      // прогноз оценки (1..5) по похожим пользователям
+	Supporters     uint         `json:"supporters"` // сколько похожих пользователей высоко оценили организацию
+	BuiltAt        time.Time    `json:"built_at"`
+}
@@ -1,5 +1,11 @@
 package model
 
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
 type User struct {
 	ID       uint   `json:"id" gorm:"primaryKey"`
 	Name     string `json:"name"`
@@ -10,80 +16,130 @@ type User struct {
 
 // Organization represents a business entity owned by a user (1:1)
 type Organization struct {
-	ID               uint                `json:"id" gorm:"primaryKey"`
-	OwnerID          uint                `json:"owner_id"` // ВНИМАНИЕ: для обычного владельца (role=owner) разрешаем только одну организацию логикой приложения; admin может иметь несколько
-	Owner            User                `json:"-" gorm:"constraint:OnDelete:CASCADE"`
-	Address          string              `json:"address"`
-	Longitude        *float64            `json:"longitude"` // optional
-	Latitude         *float64            `json:"latitude"`  // optional
-	OrganizationType string              `json:"organization_type"`
-	Params           *OrganizationParams `json:"params,omitempty" gorm:"foreignKey:OrganizationID;references:ID"`
-	MapPath          *string             `json:"map_path"`     // относительный путь к карте (изображение)
-	PicturePath      *string             `json:"picture_path"` // относительный путь к общей картинке
+	ID                uint                `json:"id" gorm:"primaryKey"`
+	OwnerID           uint                `json:"owner_id"` // ВНИМАНИЕ: для обычного владельца (role=owner) разрешаем только одну организацию логикой приложения; admin может иметь несколько
+	Owner             User                `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Name              string              `json:"name"`
+	Description       string              `json:"description"`
+	Phone             *string             `json:"phone"`
+	Website           *string             `json:"website"`
+	OpeningHours      *OpeningHours       `json:"opening_hours" gorm:"type:jsonb"` // недельное расписание с исключениями
+	QuietHours        *OpeningHours       `json:"quiet_hours" gorm:"type:jsonb"`   // «тихие часы» (приглушённый свет, без музыки) в том же формате
+	Address           string              `json:"address"`
+	AddressNormalized string              `json:"-" gorm:"index:idx_org_address_normalized"`         // см. NormalizeAddress; заполняется сервисом
+	Longitude         *float64            `json:"longitude" gorm:"index:idx_org_lat_lon,priority:2"` // optional
+	Latitude          *float64            `json:"latitude" gorm:"index:idx_org_lat_lon,priority:1"`  // optional
+	OrganizationType  string              `json:"organization_type" gorm:"index:idx_org_type"`
+	BrandID           *uint               `json:"brand_id" gorm:"index:idx_org_brand"` // сеть, к которой относится филиал
+	Brand             *Brand              `json:"-" gorm:"constraint:OnDelete:SET NULL"`
+	Params            *OrganizationParams `json:"params,omitempty" gorm:"foreignKey:OrganizationID;references:ID"`
+	MapPath           *string             `json:"map_path"`                                               // относительный путь к карте (изображение)
+	PicturePath       *string             `json:"picture_path"`                                           // относительный путь к общей картинке
+	Status            string              `json:"status" gorm:"default:active;index:idx_org_status"`      // см. OrganizationStatus*
+	DeletedAt         gorm.DeletedAt      `json:"deleted_at,omitempty" swaggertype:"string" gorm:"index"` // мягкое удаление: отзывы и агрегаты сохраняются
+	// Percentiles: param -> перцентиль (0..100) среди организаций того же типа; заполняется обработчиками
+	Percentiles map[string]float64 `json:"percentiles,omitempty" gorm:"-"`
 }
 
 // OrganizationParams aggregates ratings for an organization (1:1)
+// Besides plain avg/count/sum every param keeps a sensitivity-weighted aggregate:
+// оценка автора, отметившего параметр как важный в UserParams, весит больше (см. service.sensitiveWeight).
 type OrganizationParams struct {
 	ID             uint         `json:"id" gorm:"primaryKey"`
 	OrganizationID uint         `json:"organization_id" gorm:"uniqueIndex"`
 	Organization   Organization `json:"-" gorm:"constraint:OnDelete:CASCADE"`
 
-	AppearanceAvg   float64 `json:"appearance_avg"`
-	AppearanceCount uint    `json:"appearance_count"`
-	AppearanceSum   uint    `json:"appearance_sum"`
-
-	LightingAvg   float64 `json:"lighting_avg"`
-	LightingCount uint    `json:"lighting_count"`
-	LightingSum   uint    `json:"lighting_sum"`
-
-	SmellAvg   float64 `json:"smell_avg"`
-	SmellCount uint    `json:"smell_count"`
-	SmellSum   uint    `json:"smell_sum"`
-
-	TemperatureAvg   float64 `json:"temperature_avg"`
-	TemperatureCount uint    `json:"temperature_count"`
-	TemperatureSum   uint    `json:"temperature_sum"`
-
-	TactilityAvg   float64 `json:"tactility_avg"`
-	TactilityCount uint    `json:"tactility_count"`
-	TactilitySum   uint    `json:"tactility_sum"`
-
-	SignageAvg   float64 `json:"signage_avg"`
-	SignageCount uint    `json:"signage_count"`
-	SignageSum   uint    `json:"signage_sum"`
-
-	IntuitivenessAvg   float64 `json:"intuitiveness_avg"`
-	IntuitivenessCount uint    `json:"intuitiveness_count"`
-	IntuitivenessSum   uint    `json:"intuitiveness_sum"`
-
-	StaffAttitudeAvg   float64 `json:"staff_attitude_avg"`
-	StaffAttitudeCount uint    `json:"staff_attitude_count"`
-	StaffAttitudeSum   uint    `json:"staff_attitude_sum"`
-
-	PeopleDensityAvg   float64 `json:"people_density_avg"`
-	PeopleDensityCount uint    `json:"people_density_count"`
-	PeopleDensitySum   uint    `json:"people_density_sum"`
-
-	SelfServiceAvg   float64 `json:"self_service_avg"`
-	SelfServiceCount uint    `json:"self_service_count"`
-	SelfServiceSum   uint    `json:"self_service_sum"`
-
-	CalmnessAvg   float64 `json:"calmness_avg"`
-	CalmnessCount uint    `json:"calmness_count"`
-	CalmnessSum   uint    `json:"calmness_sum"`
+	AppearanceAvg          float64 `json:"appearance_avg"`
+	AppearanceCount        uint    `json:"appearance_count"`
+	AppearanceSum          uint    `json:"appearance_sum"`
+	AppearanceSensitiveAvg float64 `json:"appearance_sensitive_avg"`
+	AppearanceWeightSum    float64 `json:"appearance_weight_sum"`
+	AppearanceWeightedSum  float64 `json:"appearance_weighted_sum"`
+
+	LightingAvg          float64 `json:"lighting_avg"`
+	LightingCount        uint    `json:"lighting_count"`
+	LightingSum          uint    `json:"lighting_sum"`
+	LightingSensitiveAvg float64 `json:"lighting_sensitive_avg"`
+	LightingWeightSum    float64 `json:"lighting_weight_sum"`
+	LightingWeightedSum  float64 `json:"lighting_weighted_sum"`
+
+	SmellAvg          float64 `json:"smell_avg"`
+	SmellCount        uint    `json:"smell_count"`
+	SmellSum          uint    `json:"smell_sum"`
+	SmellSensitiveAvg float64 `json:"smell_sensitive_avg"`
+	SmellWeightSum    float64 `json:"smell_weight_sum"`
+	SmellWeightedSum  float64 `json:"smell_weighted_sum"`
+
+	TemperatureAvg          float64 `json:"temperature_avg"`
+	TemperatureCount        uint    `json:"temperature_count"`
+	TemperatureSum          uint    `json:"temperature_sum"`
+	TemperatureSensitiveAvg float64 `json:"temperature_sensitive_avg"`
+	TemperatureWeightSum    float64 `json:"temperature_weight_sum"`
+	TemperatureWeightedSum  float64 `json:"temperature_weighted_sum"`
+
+	TactilityAvg          float64 `json:"tactility_avg"`
+	TactilityCount        uint    `json:"tactility_count"`
+	TactilitySum          uint    `json:"tactility_sum"`
+	TactilitySensitiveAvg float64 `json:"tactility_sensitive_avg"`
+	TactilityWeightSum    float64 `json:"tactility_weight_sum"`
+	TactilityWeightedSum  float64 `json:"tactility_weighted_sum"`
+
+	SignageAvg          float64 `json:"signage_avg"`
+	SignageCount        uint    `json:"signage_count"`
+	SignageSum          uint    `json:"signage_sum"`
+	SignageSensitiveAvg float64 `json:"signage_sensitive_avg"`
+	SignageWeightSum    float64 `json:"signage_weight_sum"`
+	SignageWeightedSum  float64 `json:"signage_weighted_sum"`
+
+	IntuitivenessAvg          float64 `json:"intuitiveness_avg"`
+	IntuitivenessCount        uint    `json:"intuitiveness_count"`
+	IntuitivenessSum          uint    `json:"intuitiveness_sum"`
+	IntuitivenessSensitiveAvg float64 `json:"intuitiveness_sensitive_avg"`
+	IntuitivenessWeightSum    float64 `json:"intuitiveness_weight_sum"`
+	IntuitivenessWeightedSum  float64 `json:"intuitiveness_weighted_sum"`
+
+	StaffAttitudeAvg          float64 `json:"staff_attitude_avg"`
+	StaffAttitudeCount        uint    `json:"staff_attitude_count"`
+	StaffAttitudeSum          uint    `json:"staff_attitude_sum"`
+	StaffAttitudeSensitiveAvg float64 `json:"staff_attitude_sensitive_avg"`
+	StaffAttitudeWeightSum    float64 `json:"staff_attitude_weight_sum"`
+	StaffAttitudeWeightedSum  float64 `json:"staff_attitude_weighted_sum"`
+
+	PeopleDensityAvg          float64 `json:"people_density_avg"`
+	PeopleDensityCount        uint    `json:"people_density_count"`
+	PeopleDensitySum          uint    `json:"people_density_sum"`
+	PeopleDensitySensitiveAvg float64 `json:"people_density_sensitive_avg"`
+	PeopleDensityWeightSum    float64 `json:"people_density_weight_sum"`
+	PeopleDensityWeightedSum  float64 `json:"people_density_weighted_sum"`
+
+	SelfServiceAvg          float64 `json:"self_service_avg"`
+	SelfServiceCount        uint    `json:"self_service_count"`
+	SelfServiceSum          uint    `json:"self_service_sum"`
+	SelfServiceSensitiveAvg float64 `json:"self_service_sensitive_avg"`
+	SelfServiceWeightSum    float64 `json:"self_service_weight_sum"`
+	SelfServiceWeightedSum  float64 `json:"self_service_weighted_sum"`
+
+	CalmnessAvg          float64 `json:"calmness_avg"`
+	CalmnessCount        uint    `json:"calmness_count"`
+	CalmnessSum          uint    `json:"calmness_sum"`
+	CalmnessSensitiveAvg float64 `json:"calmness_sensitive_avg"`
+	CalmnessWeightSum    float64 `json:"calmness_weight_sum"`
+	CalmnessWeightedSum  float64 `json:"calmness_weighted_sum"`
 }
 
 // OrganizationComment represents a single user comment with optional ratings per parameter.
 // Each numeric field (Value) is optional (nil => not provided). For every non-nil value we also can store an optional text comment.
 type OrganizationComment struct {
 	ID             uint         `json:"id" gorm:"primaryKey"`
-	OrganizationID uint         `json:"organization_id" index:"idx_org_comment"`
+	OrganizationID uint         `json:"organization_id" gorm:"index:idx_org_comment"`
 	Organization   Organization `json:"-" gorm:"constraint:OnDelete:CASCADE"`
 	UserID         uint         `json:"user_id"` // author of the comment
 	User           User         `json:"-" gorm:"constraint:OnDelete:CASCADE"`
 
 	Text     *string  `json:"text"`    // общий текст комментария (опционально)
 	AvgValue *float64 `json:"avg_val"` // средняя по непустым параметрам (вычисляется при создании)
+	// CreatedAt: nil у отзывов, оставленных до появления поля
+	CreatedAt *time.Time `json:"created_at" gorm:"index:idx_org_comment_created"`
 
 	AppearanceValue      *uint   `json:"appearance_value"`
 	AppearanceComment    *string `json:"appearance_comment"`
@@ -107,4 +163,6 @@ type OrganizationComment struct {
 	SelfServiceComment   *string `json:"self_service_comment"`
 	CalmnessValue        *uint   `json:"calmness_value"`
 	CalmnessComment      *string `json:"calmness_comment"`
+
+	Response *OrganizationCommentResponse `json:"response,omitempty" gorm:"foreignKey:CommentID;constraint:OnDelete:CASCADE"` // ответ организации
 }
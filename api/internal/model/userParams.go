@@ -16,3 +16,22 @@ type UserParams struct {
 	SelfService   bool `json:"self_service"`
 	Calmness      bool `json:"calmness"`
 }
+
+// Flags returns preference flags in model.ParamNames order.
+func (u UserParams) Flags() []bool {
+	return []bool{
+		u.Appearance, u.Lighting, u.Smell, u.Temperature, u.Tactility, u.Signage,
+		u.Intuitiveness, u.StaffAttitude, u.PeopleDensity, u.SelfService, u.Calmness,
+	}
+}
+
+// SelectedParams returns canonical names of params the user marked as important.
+func (u UserParams) SelectedParams() []string {
+	var out []string
+	for i, on := range u.Flags() {
+		if on {
+			out = append(out, ParamNames[i])
+		}
+	}
+	return out
+}
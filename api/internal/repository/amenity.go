@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"slices"
+	"sort"
+
+	"2gis-calm-map/api/internal/db"
+	"2gis-calm-map/api/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SeedAmenities inserts missing catalog entries; existing rows are not modified.
+func SeedAmenities(list []model.Amenity) error {
+	if len(list) == 0 {
+		return nil
+	}
+	return db.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&list).Error
+}
+
+func ListAmenities() ([]model.Amenity, error) {
+	var list []model.Amenity
+	err := db.DB.Order("slug ASC").Find(&list).Error
+	return list, err
+}
+
+// CountAmenities returns how many of the slugs exist in the catalog.
+func CountAmenities(slugs []string) (int64, error) {
+	var n int64
+	err := db.DB.Model(&model.Amenity{}).Where("slug IN ?", slugs).Count(&n).Error
+	return n, err
+}
+
+func ListOrganizationAmenities(orgID uint) ([]model.OrganizationAmenity, error) {
+	var list []model.OrganizationAmenity
+	err := db.DB.Preload("Amenity").Where("organization_id = ?", orgID).Order("amenity_slug ASC").Find(&list).Error
+	return list, err
+}
+
+// ReplaceOrganizationAmenities sets the declared amenities: removed ones lose their votes, kept ones keep counters.
+// A change of the set is recorded as an organization revision (action=amenities) in the same transaction.
+func ReplaceOrganizationAmenities(orgID, declaredBy uint, slugs []string) error {
+	return db.DB.Transaction(func(tx *gorm.DB) error {
+		var org model.Organization
+		if err := tx.First(&org, orgID).Error; err != nil {
+			return err
+		}
+		var current []string
+		if err := tx.Model(&model.OrganizationAmenity{}).Where("organization_id = ?", orgID).
+			Order("amenity_slug ASC").Pluck("amenity_slug", &current).Error; err != nil {
+			return err
+		}
+
+		del := tx.Where("organization_id = ?", orgID)
+		delVotes := tx.Where("organization_id = ?", orgID)
+		if len(slugs) > 0 {
+			del = del.Where("amenity_slug NOT IN ?", slugs)
+			delVotes = delVotes.Where("amenity_slug NOT IN ?", slugs)
+		}
+		if err := del.Delete(&model.OrganizationAmenity{}).Error; err != nil {
+			return err
+		}
+		if err := delVotes.Delete(&model.AmenityVote{}).Error; err != nil {
+			return err
+		}
+		if len(slugs) > 0 {
+			rows := make([]model.OrganizationAmenity, 0, len(slugs))
+			for _, s := range slugs {
+				rows = append(rows, model.OrganizationAmenity{OrganizationID: orgID, AmenitySlug: s, DeclaredBy: declaredBy})
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+				return err
+			}
+		}
+
+		declared := append([]string{}, slugs...)
+		sort.Strings(declared)
+		if current == nil {
+			current = []string{}
+		}
+		if slices.Equal(current, declared) {
+			return nil
+		}
+		before, after := model.SnapshotOf(org), model.SnapshotOf(org)
+		before.Amenities, after.Amenities = &current, &declared
+		return tx.Create(&model.OrganizationRevision{
+			OrganizationID: orgID,
+			ActorID:        declaredBy,
+			Action:         model.RevisionActionAmenities,
+			Before:         before,
+			After:          after,
+		}).Error
+	})
+}
+
+func GetOrganizationAmenity(orgID uint, slug string) (model.OrganizationAmenity, error) {
+	var a model.OrganizationAmenity
+	err := db.DB.Preload("Amenity").Where("organization_id = ? AND amenity_slug = ?", orgID, slug).First(&a).Error
+	return a, err
+}
+
+// UpsertAmenityVote stores the user's vote and recounts confirmations/disputes of the amenity.
+func UpsertAmenityVote(v *model.AmenityVote) (model.OrganizationAmenity, error) {
+	var a model.OrganizationAmenity
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "organization_id"}, {Name: "amenity_slug"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"confirmed", "updated_at"}),
+		}).Create(v).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(
+			"UPDATE organization_amenities SET "+
+				"confirmations = (SELECT COUNT(*) FROM amenity_votes v WHERE v.organization_id = organization_amenities.organization_id AND v.amenity_slug = organization_amenities.amenity_slug AND v.confirmed), "+
+				"disputes = (SELECT COUNT(*) FROM amenity_votes v WHERE v.organization_id = organization_amenities.organization_id AND v.amenity_slug = organization_amenities.amenity_slug AND NOT v.confirmed) "+
+				"WHERE organization_id = ? AND amenity_slug = ?",
+			v.OrganizationID, v.AmenitySlug,
+		).Error; err != nil {
+			return err
+		}
+		return tx.Preload("Amenity").Where("organization_id = ? AND amenity_slug = ?", v.OrganizationID, v.AmenitySlug).First(&a).Error
+	})
+	return a, err
+}
+
+// ListUserAmenityVotes returns the user's votes on the organization's amenities (slug -> confirmed).
+func ListUserAmenityVotes(orgID, userID uint) (map[string]bool, error) {
+	var list []model.AmenityVote
+	if err := db.DB.Where("organization_id = ? AND user_id = ?", orgID, userID).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	votes := make(map[string]bool, len(list))
+	for _, v := range list {
+		votes[v.AmenitySlug] = v.Confirmed
+	}
+	return votes, nil
+}
+
+// whereAmenities keeps organizations that declare every slug and whose declaration is not disputed by most voters.
+func whereAmenities(q *gorm.DB, slugs []string) *gorm.DB {
+	if len(slugs) == 0 {
+		return q
+	}
+	return q.Where(
+		"(SELECT COUNT(*) FROM organization_amenities oa WHERE oa.organization_id = organizations.id AND oa.amenity_slug IN ? AND oa.disputes <= oa.confirmations) = ?",
+		slugs, len(slugs),
+	)
+}
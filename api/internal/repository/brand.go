@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+
+	"2gis-calm-map/api/internal/db"
+	"2gis-calm-map/api/internal/model"
+)
+
+func CreateBrand(b *model.Brand) error {
+	return db.DB.Create(b).Error
+}
+
+func GetBrand(id uint) (model.Brand, error) {
+	var b model.Brand
+	err := db.DB.First(&b, id).Error
+	return b, err
+}
+
+func UpdateBrand(id uint, updates map[string]interface{}) (model.Brand, error) {
+	if err := db.DB.Model(&model.Brand{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return model.Brand{}, err
+	}
+	return GetBrand(id)
+}
+
+// BrandWithCount is a brand with the number of its active branches.
+type BrandWithCount struct {
+	model.Brand
+	BranchCount int64 `json:"branch_count"`
+}
+
+func ListBrands(ownerID uint, limit, offset int) ([]BrandWithCount, error) {
+	var list []BrandWithCount
+	q := db.DB.Model(&model.Brand{}).
+		Select("brands.*, (SELECT COUNT(*) FROM organizations WHERE organizations.brand_id = brands.id AND " + activeOrganizationSQL + ") AS branch_count").
+		Order("brands.name ASC").Order("brands.id ASC").
+		Offset(offset)
+	if ownerID != 0 {
+		q = q.Where("brands.owner_id = ?", ownerID)
+	}
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	err := q.Find(&list).Error
+	return list, err
+}
+
+// ListBrandOrganizations returns branches with params; archived ones only if includeArchived.
+func ListBrandOrganizations(brandID uint, includeArchived bool) ([]model.Organization, error) {
+	var orgs []model.Organization
+	q := db.DB.Joins("Params").Where("organizations.brand_id = ?", brandID)
+	if !includeArchived {
+		q = q.Where(activeOrganizationSQL)
+	}
+	err := q.Order("organizations.id ASC").Find(&orgs).Error
+	return orgs, err
+}
+
+// FactorAggregate is a factor aggregated over a group of active organizations:
+// Average = sum of ratings / number of ratings (organizations with more reviews weigh more).
+type FactorAggregate struct {
+	Param            string  `json:"param"`
+	Average          float64 `json:"average"`
+	SensitiveAverage float64 `json:"sensitive_average"`
+	Count            int64   `json:"count"`
+}
+
+// BrandFactors aggregates factors over active branches of a brand.
+func BrandFactors(brandID uint) ([]FactorAggregate, error) {
+	return aggregateFactors("organizations.brand_id = ?", brandID)
+}
+
+// TypeFactors aggregates factors over active organizations of a type.
+func TypeFactors(orgType string) ([]FactorAggregate, error) {
+	return aggregateFactors("organizations.organization_type = ?", orgType)
+}
+
+// aggregateFactors sums raw aggregates (<name>_sum, _count, _weighted_sum, _weight_sum) of matching organizations in one query.
+func aggregateFactors(where string, args ...interface{}) ([]FactorAggregate, error) {
+	cols := make([]string, 0, len(model.ParamNames)*3)
+	for _, name := range model.ParamNames {
+		cols = append(cols,
+			"COALESCE(SUM(p."+name+"_sum)::float8 / NULLIF(SUM(p."+name+"_count), 0), 0)",
+			"COALESCE(SUM(p."+name+"_weighted_sum) / NULLIF(SUM(p."+name+"_weight_sum), 0), 0)",
+			"COALESCE(SUM(p."+name+"_count), 0)::bigint",
+		)
+	}
+	row := db.DB.Raw(
+		"SELECT "+strings.Join(cols, ", ")+
+			" FROM organizations JOIN organization_params p ON p.organization_id = organizations.id"+
+			" WHERE "+where+" AND "+activeOrganizationSQL,
+		args...,
+	).Row()
+
+	factors := make([]FactorAggregate, len(model.ParamNames))
+	dest := make([]interface{}, 0, len(cols))
+	for i := range factors {
+		factors[i].Param = model.ParamNames[i]
+		dest = append(dest, &factors[i].Average, &factors[i].SensitiveAverage, &factors[i].Count)
+	}
+	if err := row.Scan(dest...); err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	return factors, nil
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"math"
+
+	"gorm.io/gorm"
+)
+
+const earthRadiusMeters = 6371000.0
+
+// distanceMetersExpr returns SQL (haversine) distance in meters from (latCol, lonCol) to a point.
+// Placeholders are bound by distanceArgs.
+func distanceMetersExpr(latCol, lonCol string) string {
+	return "2 * 6371000 * asin(sqrt(power(sin(radians(" + latCol + " - ?) / 2), 2) + cos(radians(?)) * cos(radians(" + latCol + ")) * power(sin(radians(" + lonCol + " - ?) / 2), 2)))"
+}
+
+func distanceArgs(lat, lon float64) []interface{} {
+	return []interface{}{lat, lat, lon}
+}
+
+// whereWithinRadius keeps rows within radius meters: cheap bounding box first (uses indexes), then exact distance.
+func whereWithinRadius(q *gorm.DB, latCol, lonCol string, lat, lon, radius float64) *gorm.DB {
+	dLat := radius / earthRadiusMeters * 180 / math.Pi
+	dLon := dLat / math.Max(math.Cos(lat*math.Pi/180), 0.01)
+	return q.Where(latCol+" BETWEEN ? AND ?", lat-dLat, lat+dLat).
+		Where(lonCol+" BETWEEN ? AND ?", lon-dLon, lon+dLon).
+		Where(distanceMetersExpr(latCol, lonCol)+" <= ?", append(distanceArgs(lat, lon), radius)...)
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"2gis-calm-map/api/internal/db"
+	"2gis-calm-map/api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ReplaceLeaderboard swaps entries of one (type, area, preset) board in a transaction.
+func ReplaceLeaderboard(orgType, area, preset string, entries []model.LeaderboardEntry) error {
+	return db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("organization_type = ? AND area = ? AND preset = ?", orgType, area, preset).
+			Delete(&model.LeaderboardEntry{}).Error; err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+		return tx.Create(&entries).Error
+	})
+}
+
+// ListLeaderboard returns board entries with organizations; empty preset means all presets.
+// Organizations archived or deleted since the last rebuild are skipped (their places stay until the next rebuild).
+func ListLeaderboard(orgType, area, preset string, limit int) ([]model.LeaderboardEntry, error) {
+	var list []model.LeaderboardEntry
+	q := db.DB.Preload("Organization").
+		Joins("JOIN organizations ON organizations.id = leaderboard_entries.organization_id").
+		Where(activeOrganizationSQL).
+		Where("leaderboard_entries.organization_type = ? AND leaderboard_entries.area = ?", orgType, area)
+	if preset != "" {
+		q = q.Where("leaderboard_entries.preset = ?", preset)
+	}
+	if limit > 0 {
+		q = q.Where("leaderboard_entries.rank <= ?", limit)
+	}
+	err := q.Order("leaderboard_entries.preset ASC").Order("leaderboard_entries.rank ASC").Find(&list).Error
+	return list, err
+}
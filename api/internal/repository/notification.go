@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"time"
+
+	"2gis-calm-map/api/internal/db"
+	"2gis-calm-map/api/internal/model"
+)
+
+func CreateNotifications(list []model.Notification) error {
+	if len(list) == 0 {
+		return nil
+	}
+	return db.DB.Create(&list).Error
+}
+
+// ListNotifications returns newest notifications of a user first.
+func ListNotifications(userID uint, unreadOnly bool, limit int) ([]model.Notification, error) {
+	var list []model.Notification
+	q := db.DB.Where("user_id = ?", userID)
+	if unreadOnly {
+		q = q.Where("read_at IS NULL")
+	}
+	err := q.Order("created_at DESC").Order("id DESC").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+// MarkNotificationRead sets read_at; returns false if the notification does not belong to the user.
+func MarkNotificationRead(userID, id uint, at time.Time) (bool, error) {
+	res := db.DB.Model(&model.Notification{}).
+		Where("id = ? AND user_id = ? AND read_at IS NULL", id, userID).
+		Update("read_at", at)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected > 0 {
+		return true, nil
+	}
+	// уже прочитано — тоже успех, если уведомление своё
+	var count int64
+	err := db.DB.Model(&model.Notification{}).Where("id = ? AND user_id = ?", id, userID).Count(&count).Error
+	return count > 0, err
+}
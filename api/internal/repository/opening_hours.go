@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"2gis-calm-map/api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// scheduleAtSQL checks a schedule column (jsonb, see model.OpeningHours; "{col}" placeholder) at a moment given as timestamptz.
+// Local time is taken in the organization's timezone. The local day is checked against its exception if there is one,
+// otherwise against the weekly intervals of that weekday; independently, the overnight intervals of the previous day
+// (again its exception if present, else the weekly schedule) are checked for the tail after midnight.
+// "HH:MM" strings are compared lexicographically. Organizations without a schedule never match.
+const scheduleAtSQL = `EXISTS (SELECT 1 FROM (SELECT CAST(? AS timestamptz) AT TIME ZONE COALESCE(NULLIF({col}->>'timezone', ''), ?) AS lt) n
+WHERE (CASE WHEN EXISTS (SELECT 1 FROM jsonb_array_elements(COALESCE({col}->'exceptions', '[]'::jsonb)) e WHERE e->>'date' = to_char(n.lt, 'YYYY-MM-DD'))
+THEN EXISTS (SELECT 1 FROM jsonb_array_elements(COALESCE({col}->'exceptions', '[]'::jsonb)) e, jsonb_array_elements(COALESCE(e->'intervals', '[]'::jsonb)) i
+	WHERE e->>'date' = to_char(n.lt, 'YYYY-MM-DD') AND NOT COALESCE((e->>'closed')::boolean, false)
+	AND i->>'opens' <= to_char(n.lt, 'HH24:MI') AND (i->>'closes' > to_char(n.lt, 'HH24:MI') OR i->>'closes' <= i->>'opens'))
+ELSE EXISTS (SELECT 1 FROM jsonb_array_elements(COALESCE({col}->'weekly', '[]'::jsonb)) w
+	WHERE (w->>'day')::int = EXTRACT(ISODOW FROM n.lt) AND w->>'opens' <= to_char(n.lt, 'HH24:MI') AND (w->>'closes' > to_char(n.lt, 'HH24:MI') OR w->>'closes' <= w->>'opens'))
+END)
+OR (CASE WHEN EXISTS (SELECT 1 FROM jsonb_array_elements(COALESCE({col}->'exceptions', '[]'::jsonb)) e WHERE e->>'date' = to_char(n.lt - interval '1 day', 'YYYY-MM-DD'))
+THEN EXISTS (SELECT 1 FROM jsonb_array_elements(COALESCE({col}->'exceptions', '[]'::jsonb)) e, jsonb_array_elements(COALESCE(e->'intervals', '[]'::jsonb)) i
+	WHERE e->>'date' = to_char(n.lt - interval '1 day', 'YYYY-MM-DD') AND NOT COALESCE((e->>'closed')::boolean, false)
+	AND i->>'closes' <= i->>'opens' AND to_char(n.lt, 'HH24:MI') < i->>'closes')
+ELSE EXISTS (SELECT 1 FROM jsonb_array_elements(COALESCE({col}->'weekly', '[]'::jsonb)) w
+	WHERE (w->>'day')::int = EXTRACT(ISODOW FROM n.lt - interval '1 day') AND w->>'closes' <= w->>'opens' AND to_char(n.lt, 'HH24:MI') < w->>'closes')
+END))`
+
+func whereScheduleAt(q *gorm.DB, column string, t time.Time) *gorm.DB {
+	return q.Where(column+" IS NOT NULL").Where(strings.ReplaceAll(scheduleAtSQL, "{col}", column), t, model.DefaultTimezone)
+}
+
+// whereOpenAt keeps organizations that are open at t according to their opening hours.
+func whereOpenAt(q *gorm.DB, t time.Time) *gorm.DB {
+	return whereScheduleAt(q, "organizations.opening_hours", t)
+}
+
+// whereQuietAt keeps organizations whose quiet hours include t.
+func whereQuietAt(q *gorm.DB, t time.Time) *gorm.DB {
+	return whereScheduleAt(q, "organizations.quiet_hours", t)
+}
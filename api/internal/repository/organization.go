@@ -5,25 +5,18 @@ import (
 	"2gis-calm-map/api/internal/model"
 )
 
-func CreateOrganization(org *model.Organization) error {
-	return db.DB.Create(org).Error
-}
-
-func GetOrganizationByOwner(ownerID uint) (model.Organization, error) {
-	var org model.Organization
-	err := db.DB.Where("owner_id = ?", ownerID).First(&org).Error
-	return org, err
-}
+// activeOrganizationSQL keeps organizations visible in search and rankings (not archived, not deleted).
+const activeOrganizationSQL = "organizations.status = 'active' AND organizations.deleted_at IS NULL"
 
-func UpdateOrganizationByOwner(ownerID uint, updates map[string]interface{}) (model.Organization, error) {
-	var org model.Organization
-	if err := db.DB.Where("owner_id = ?", ownerID).First(&org).Error; err != nil {
-		return org, err
-	}
-	if err := db.DB.Model(&org).Updates(updates).Error; err != nil {
-		return org, err
+// ListOrganizationsByOwner returns organizations of the owner, oldest first (at most limit if limit > 0).
+func ListOrganizationsByOwner(ownerID uint, limit int) ([]model.Organization, error) {
+	var orgs []model.Organization
+	q := db.DB.Where("owner_id = ?", ownerID).Order("id ASC")
+	if limit > 0 {
+		q = q.Limit(limit)
 	}
-	return org, nil
+	err := q.Find(&orgs).Error
+	return orgs, err
 }
 
 func GetOrganizationsByType(orgType string) ([]model.Organization, error) {
@@ -47,3 +40,72 @@ func GetOrganizationByAddress(address string) (model.Organization, error) {
 	err := db.DB.Where("address = ?", address).First(&org).Error
 	return org, err
 }
+
+// GetOrganizationsByIDs loads organizations with params (single LEFT JOIN) by ids; order is not guaranteed.
+func GetOrganizationsByIDs(ids []uint) ([]model.Organization, error) {
+	var orgs []model.Organization
+	if len(ids) == 0 {
+		return orgs, nil
+	}
+	err := db.DB.Joins("Params").Where("organizations.id IN ?", ids).Find(&orgs).Error
+	return orgs, err
+}
+
+func GetOrganizationByNormalizedAddress(normalized string) (model.Organization, error) {
+	var org model.Organization
+	err := db.DB.Where("address_normalized = ?", normalized).Order("id ASC").First(&org).Error
+	return org, err
+}
+
+func ListOrganizationsByNormalizedAddress(normalized string) ([]model.Organization, error) {
+	var orgs []model.Organization
+	err := db.DB.Where("address_normalized = ?", normalized).Order("id ASC").Find(&orgs).Error
+	return orgs, err
+}
+
+// ListOrganizationsNear returns organizations within radius meters of the point.
+func ListOrganizationsNear(lat, lon, radius float64) ([]model.Organization, error) {
+	var orgs []model.Organization
+	q := whereWithinRadius(db.DB.Model(&model.Organization{}), "organizations.latitude", "organizations.longitude", lat, lon, radius)
+	err := q.Order("organizations.id ASC").Find(&orgs).Error
+	return orgs, err
+}
+
+// AddressCandidate is an organization whose normalized address is similar to the requested one.
+type AddressCandidate struct {
+	model.Organization
+	Similarity float64
+}
+
+// FindOrganizationsBySimilarAddress uses pg_trgm: % narrows by the trigram GIN index, then similarity >= min.
+func FindOrganizationsBySimilarAddress(normalized string, minSimilarity float64, limit int) ([]AddressCandidate, error) {
+	var list []AddressCandidate
+	err := db.DB.Model(&model.Organization{}).
+		Select("organizations.*, similarity(organizations.address_normalized, ?) AS similarity", normalized).
+		Where("organizations.address_normalized % ?", normalized).
+		Where("similarity(organizations.address_normalized, ?) >= ?", normalized, minSimilarity).
+		Order("similarity DESC").Order("organizations.id ASC").
+		Limit(limit).
+		Find(&list).Error
+	return list, err
+}
+
+// ListOrganizationsWithoutNormalizedAddress returns rows created before address normalization existed.
+func ListOrganizationsWithoutNormalizedAddress() ([]model.Organization, error) {
+	var orgs []model.Organization
+	err := db.DB.Where("address_normalized = '' OR address_normalized IS NULL").Where("address <> ''").Find(&orgs).Error
+	return orgs, err
+}
+
+func ListOrganizationsWithoutCoordinates() ([]model.Organization, error) {
+	var orgs []model.Organization
+	err := db.DB.Where("latitude IS NULL OR longitude IS NULL").Order("id ASC").Find(&orgs).Error
+	return orgs, err
+}
+
+// GetOrganizationByIDUnscoped also finds soft-deleted organizations (for admins).
+func GetOrganizationByIDUnscoped(id uint) (model.Organization, error) {
+	var org model.Organization
+	err := db.DB.Unscoped().Preload("Params").First(&org, id).Error
+	return org, err
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"2gis-calm-map/api/internal/db"
+	"2gis-calm-map/api/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrClaimNotPending is returned when a claim has already been reviewed.
+var ErrClaimNotPending = errors.New("claim is not pending")
+
+// CreateOrganizationClaim stores a pending claim together with its first history event.
+func CreateOrganizationClaim(claim *model.OrganizationClaim) error {
+	return db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("History").Create(claim).Error; err != nil {
+			return err
+		}
+		ev := model.OrganizationClaimEvent{ClaimID: claim.ID, Status: claim.Status, ActorID: claim.ClaimantID, Note: "submitted"}
+		if err := tx.Create(&ev).Error; err != nil {
+			return err
+		}
+		claim.History = []model.OrganizationClaimEvent{ev}
+		return nil
+	})
+}
+
+func HasPendingOrganizationClaim(orgID, claimantID uint) (bool, error) {
+	var count int64
+	err := db.DB.Model(&model.OrganizationClaim{}).
+		Where("organization_id = ? AND claimant_id = ? AND status = ?", orgID, claimantID, model.ClaimStatusPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func GetOrganizationClaim(id uint) (model.OrganizationClaim, error) {
+	var claim model.OrganizationClaim
+	err := db.DB.Preload("History", func(tx *gorm.DB) *gorm.DB { return tx.Order("id ASC") }).First(&claim, id).Error
+	return claim, err
+}
+
+// ListOrganizationClaims filters by claimant (0 = any) and status ("" = any); newest first.
+func ListOrganizationClaims(claimantID uint, status string) ([]model.OrganizationClaim, error) {
+	var list []model.OrganizationClaim
+	q := db.DB.Preload("History", func(tx *gorm.DB) *gorm.DB { return tx.Order("id ASC") })
+	if claimantID != 0 {
+		q = q.Where("claimant_id = ?", claimantID)
+	}
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+	err := q.Order("created_at DESC").Order("id DESC").Find(&list).Error
+	return list, err
+}
+
+// ClaimReview is the outcome of ReviewOrganizationClaim.
+type ClaimReview struct {
+	Claim           model.OrganizationClaim
+	AutoRejected    []model.OrganizationClaim // другие ожидающие заявки на ту же организацию (при одобрении)
+	TransferredFrom uint                      // прежний владелец (при одобрении)
+}
+
+// ReviewOrganizationClaim approves or rejects a pending claim in one transaction. On approval the organization's
+// owner_id is transferred to the claimant and other pending claims for the organization are rejected.
+// checkApprove runs inside the transaction before the transfer (e.g. ownership limits).
+func ReviewOrganizationClaim(id, reviewerID uint, approve bool, note string, checkApprove func(tx *gorm.DB, claim model.OrganizationClaim) error) (ClaimReview, error) {
+	var out ClaimReview
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		var claim model.OrganizationClaim
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&claim, id).Error; err != nil {
+			return err
+		}
+		if claim.Status != model.ClaimStatusPending {
+			return ErrClaimNotPending
+		}
+		now := time.Now()
+		status := model.ClaimStatusRejected
+		updates := map[string]interface{}{"reviewer_id": reviewerID, "review_note": note, "reviewed_at": now}
+		if approve {
+			if checkApprove != nil {
+				if err := checkApprove(tx, claim); err != nil {
+					return err
+				}
+			}
+			var org model.Organization
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&org, claim.OrganizationID).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&org).Update("owner_id", claim.ClaimantID).Error; err != nil {
+				return err
+			}
+			before, after := model.SnapshotOf(org), model.SnapshotOf(org)
+			after.OwnerID = claim.ClaimantID
+			if err := tx.Create(&model.OrganizationRevision{
+				OrganizationID: org.ID,
+				ActorID:        reviewerID,
+				Action:         model.RevisionActionOwner,
+				Before:         before,
+				After:          after,
+			}).Error; err != nil {
+				return err
+			}
+			status = model.ClaimStatusApproved
+			updates["previous_owner_id"] = org.OwnerID
+			out.TransferredFrom = org.OwnerID
+
+			// остальные заявки на эту организацию теряют смысл
+			if err := tx.Where("organization_id = ? AND status = ? AND id <> ?", claim.OrganizationID, model.ClaimStatusPending, claim.ID).
+				Find(&out.AutoRejected).Error; err != nil {
+				return err
+			}
+			for _, other := range out.AutoRejected {
+				if err := tx.Model(&model.OrganizationClaim{}).Where("id = ?", other.ID).Updates(map[string]interface{}{
+					"status": model.ClaimStatusRejected, "reviewer_id": reviewerID, "review_note": "another claim was approved", "reviewed_at": now,
+				}).Error; err != nil {
+					return err
+				}
+				if err := tx.Create(&model.OrganizationClaimEvent{ClaimID: other.ID, Status: model.ClaimStatusRejected, ActorID: reviewerID, Note: "another claim was approved"}).Error; err != nil {
+					return err
+				}
+			}
+		}
+		updates["status"] = status
+		if err := tx.Model(&model.OrganizationClaim{}).Where("id = ?", claim.ID).Updates(updates).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.OrganizationClaimEvent{ClaimID: claim.ID, Status: status, ActorID: reviewerID, Note: note}).Error
+	})
+	if err != nil {
+		return out, err
+	}
+	out.Claim, err = GetOrganizationClaim(id)
+	return out, err
+}
+
+// CountOrganizationsByOwner is used to enforce "one organization per owner" (inside a transaction if tx is set).
+func CountOrganizationsByOwner(tx *gorm.DB, ownerID uint) (int64, error) {
+	if tx == nil {
+		tx = db.DB
+	}
+	var count int64
+	err := tx.Model(&model.Organization{}).Where("owner_id = ?", ownerID).Count(&count).Error
+	return count, err
+}
@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"strings"
+	"time"
+
 	"2gis-calm-map/api/internal/db"
 	"2gis-calm-map/api/internal/model"
 )
@@ -11,6 +14,96 @@ func CreateOrganizationComment(c *model.OrganizationComment) error {
 
 func ListOrganizationComments(orgID uint) ([]model.OrganizationComment, error) {
 	var list []model.OrganizationComment
-	err := db.DB.Preload("User").Where("organization_id = ?", orgID).Order("id DESC").Find(&list).Error
+	err := db.DB.Preload("User").Preload("Response").Where("organization_id = ?", orgID).Order("id DESC").Find(&list).Error
+	return list, err
+}
+
+func GetOrganizationComment(orgID, id uint) (model.OrganizationComment, error) {
+	var c model.OrganizationComment
+	err := db.DB.Preload("Response").Where("organization_id = ?", orgID).First(&c, id).Error
+	return c, err
+}
+
+// ListRecentOrganizationComments returns the latest reviews of the organization (newest first).
+func ListRecentOrganizationComments(orgID uint, limit int) ([]model.OrganizationComment, error) {
+	var list []model.OrganizationComment
+	err := db.DB.Preload("User").Preload("Response").Where("organization_id = ?", orgID).Order("id DESC").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+// CountOrganizationComments returns the number of reviews of the organization and how many of them have no created_at.
+func CountOrganizationComments(orgID uint) (total, undated int64, err error) {
+	err = db.DB.Raw(
+		"SELECT COUNT(*), COUNT(*) FILTER (WHERE created_at IS NULL) FROM organization_comments WHERE organization_id = ?", orgID,
+	).Row().Scan(&total, &undated)
+	return total, undated, err
+}
+
+// ReviewVolumeBucket is the number of dated reviews in one period and their mean avg_value (nil if none is rated).
+// PeriodStart is the local wall clock of the period start, returned in UTC.
+type ReviewVolumeBucket struct {
+	PeriodStart time.Time
+	Reviews     int
+	Average     *float64
+}
+
+// ReviewVolume groups reviews of the organization created since the given moment by period
+// (a date_trunc field: day, week or month) of their local time in tz; periods without reviews are absent.
+func ReviewVolume(orgID uint, period, tz string, since time.Time) ([]ReviewVolumeBucket, error) {
+	var list []ReviewVolumeBucket
+	err := db.DB.Raw(
+		"SELECT date_trunc(?, created_at AT TIME ZONE ?) AS period_start, COUNT(*) AS reviews, AVG(avg_value) AS average "+
+			"FROM organization_comments WHERE organization_id = ? AND created_at >= ? GROUP BY 1",
+		period, tz, orgID, since,
+	).Scan(&list).Error
+	return list, err
+}
+
+// ListOrganizationCommentTexts returns reviews of the organization that have per-factor comments,
+// with only the <name>_comment columns loaded.
+func ListOrganizationCommentTexts(orgID uint) ([]model.OrganizationComment, error) {
+	cols := make([]string, len(model.ParamNames))
+	for i, name := range model.ParamNames {
+		cols[i] = name + "_comment"
+	}
+	var list []model.OrganizationComment
+	err := db.DB.Select(cols).
+		Where("organization_id = ?", orgID).
+		Where("COALESCE(" + strings.Join(cols, ", ") + ") IS NOT NULL").
+		Find(&list).Error
 	return list, err
 }
+
+// HasUserCommented reports whether the user has left a review of the organization.
+func HasUserCommented(orgID, userID uint) (bool, error) {
+	var n int64
+	err := db.DB.Model(&model.OrganizationComment{}).Where("organization_id = ? AND user_id = ?", orgID, userID).Limit(1).Count(&n).Error
+	return n > 0, err
+}
+
+// UpsertCommentResponse creates the reply to a review or, if the review already has one, replaces its text and author
+// in a single statement; inserted is false for an edit (xmax = 0 only for a freshly inserted row).
+func UpsertCommentResponse(r *model.OrganizationCommentResponse) (bool, error) {
+	var row struct {
+		model.OrganizationCommentResponse
+		Inserted bool
+	}
+	now := time.Now()
+	err := db.DB.Raw(
+		"INSERT INTO organization_comment_responses (comment_id, organization_id, author_id, text, created_at, updated_at) "+
+			"VALUES (?, ?, ?, ?, ?, ?) "+
+			"ON CONFLICT (comment_id) DO UPDATE SET author_id = EXCLUDED.author_id, text = EXCLUDED.text, updated_at = EXCLUDED.updated_at "+
+			"RETURNING *, (xmax = 0) AS inserted",
+		r.CommentID, r.OrganizationID, r.AuthorID, r.Text, now, now,
+	).Scan(&row).Error
+	if err != nil {
+		return false, err
+	}
+	*r = row.OrganizationCommentResponse
+	return row.Inserted, nil
+}
+
+func DeleteCommentResponse(commentID uint) (bool, error) {
+	res := db.DB.Where("comment_id = ?", commentID).Delete(&model.OrganizationCommentResponse{})
+	return res.RowsAffected > 0, res.Error
+}
@@ -1,6 +1,11 @@
 package repository
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
 	"2gis-calm-map/api/internal/db"
 	"2gis-calm-map/api/internal/model"
 )
@@ -20,3 +25,131 @@ func CreateEmptyOrganizationParams(orgID uint) (model.OrganizationParams, error)
 func UpdateOrganizationParams(p *model.OrganizationParams) error {
 	return db.DB.Save(p).Error
 }
+
+// OrganizationAverageFilter describes a by-type search evaluated in SQL.
+// Params and MinParams keys must be canonical names (see model.ParamNames).
+type OrganizationAverageFilter struct {
+	OrganizationType string
+	Params           []string
+	Threshold        float64            // combined average must be strictly greater
+	MinParams        map[string]float64 // per-factor minimum (avg >= value)
+	MinReviews       uint
+	Sort             string     // average_desc | average_asc | reviews_desc
+	Aggregate        string     // plain (default) | sensitivity, see model.AggregateColumnSuffix
+	OpenAt           *time.Time // если задано — только открытые в этот момент (см. model.OpeningHours)
+	QuietAt          *time.Time // если задано — только в «тихие часы» в этот момент
+	IncludeArchived  bool       // по умолчанию закрытые (status != active) не попадают в выдачу
+	BrandID          *uint      // только филиалы бренда
+	Amenities        []string   // slugs; все должны быть объявлены и не оспорены (см. whereAmenities)
+	Limit            int        // 0 = no limit
+	Offset           int
+}
+
+// OrganizationAverageRow is a single match of OrganizationAverageFilter: organization (with Params) and computed columns.
+type OrganizationAverageRow struct {
+	model.Organization
+	Average     float64
+	ReviewCount int64
+}
+
+// selectedAverageExpr builds SQL equivalent of OrganizationParamsService.ComputeAverageAcross:
+// mean of the selected average columns (suffix: _avg or _sensitive_avg) that are > 0, or 0 if none is rated.
+func selectedAverageExpr(params []string, suffix string) string {
+	sums := make([]string, 0, len(params))
+	counts := make([]string, 0, len(params))
+	for _, name := range params {
+		col := `COALESCE("Params".` + name + suffix + ", 0)"
+		sums = append(sums, "CASE WHEN "+col+" > 0 THEN "+col+" ELSE 0 END")
+		counts = append(counts, "CASE WHEN "+col+" > 0 THEN 1 ELSE 0 END")
+	}
+	return "COALESCE((" + strings.Join(sums, " + ") + ") / NULLIF(" + strings.Join(counts, " + ") + ", 0), 0)"
+}
+
+// SearchOrganizationAverages filters and ranks organizations of a type by selected params.
+// Everything (params join, average, review count, paging) is done by a single read-only query.
+func SearchOrganizationAverages(f OrganizationAverageFilter) ([]OrganizationAverageRow, error) {
+	for _, name := range f.Params {
+		if _, ok := model.NormalizeParamName(name); !ok {
+			return nil, fmt.Errorf("unknown param: %s", name)
+		}
+	}
+	suffix := model.AggregateColumnSuffix(f.Aggregate)
+	avgExpr := selectedAverageExpr(f.Params, suffix)
+	reviewsExpr := "(SELECT COUNT(*) FROM organization_comments c WHERE c.organization_id = organizations.id)"
+
+	q := db.DB.Model(&model.Organization{}).
+		Select("organizations.*", avgExpr+" AS average", reviewsExpr+" AS review_count").
+		Joins("Params").
+		Where("organizations.organization_type = ?", f.OrganizationType).
+		Where(avgExpr+" > ?", f.Threshold)
+
+	// сортируем ключи, чтобы SQL был детерминированным
+	names := make([]string, 0, len(f.MinParams))
+	for name := range f.MinParams {
+		if _, ok := model.NormalizeParamName(name); !ok {
+			return nil, fmt.Errorf("unknown param: %s", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		q = q.Where(`COALESCE("Params".`+name+suffix+", 0) >= ?", f.MinParams[name])
+	}
+	if f.MinReviews > 0 {
+		q = q.Where(reviewsExpr+" >= ?", f.MinReviews)
+	}
+	if !f.IncludeArchived {
+		q = q.Where(activeOrganizationSQL)
+	}
+	if f.BrandID != nil {
+		q = q.Where("organizations.brand_id = ?", *f.BrandID)
+	}
+	q = whereAmenities(q, f.Amenities)
+	if f.OpenAt != nil {
+		q = whereOpenAt(q, *f.OpenAt)
+	}
+	if f.QuietAt != nil {
+		q = whereQuietAt(q, *f.QuietAt)
+	}
+
+	switch f.Sort {
+	case "average_asc":
+		q = q.Order("average ASC")
+	case "reviews_desc":
+		q = q.Order("review_count DESC").Order("average DESC")
+	default:
+		q = q.Order("average DESC")
+	}
+	q = q.Order("organizations.id ASC")
+	if f.Limit > 0 {
+		q = q.Limit(f.Limit)
+	}
+	if f.Offset > 0 {
+		q = q.Offset(f.Offset)
+	}
+
+	var rows []OrganizationAverageRow
+	err := q.Find(&rows).Error
+	return rows, err
+}
+
+// BackfillSensitivityAggregates fills weighted aggregates for rows created before they existed
+// (weight_sum = 0 while count > 0), recomputing them from comments and authors' UserParams.
+func BackfillSensitivityAggregates(flaggedWeight float64) error {
+	for _, name := range model.ParamNames {
+		err := db.DB.Exec(
+			"UPDATE organization_params p SET "+name+"_weighted_sum = s.ws, "+name+"_weight_sum = s.w, "+name+"_sensitive_avg = s.ws / s.w "+
+				"FROM (SELECT c.organization_id, "+
+				"SUM(c."+name+"_value * CASE WHEN up."+name+" THEN CAST(? AS double precision) ELSE 1.0 END) AS ws, "+
+				"SUM(CASE WHEN up."+name+" THEN CAST(? AS double precision) ELSE 1.0 END) AS w "+
+				"FROM organization_comments c LEFT JOIN user_params up ON up.user_id = c.user_id "+
+				"WHERE c."+name+"_value > 0 GROUP BY c.organization_id) s "+
+				"WHERE s.organization_id = p.organization_id AND p."+name+"_weight_sum = 0 AND p."+name+"_count > 0",
+			flaggedWeight, flaggedWeight,
+		).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
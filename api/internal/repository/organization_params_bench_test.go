@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+
+	"2gis-calm-map/api/internal/db"
+	"2gis-calm-map/api/internal/model"
+)
+
+// benchOrganizations is the catalog size seeded for by-type search benchmarks.
+const benchOrganizations = 5000
+
+// seedBenchOrganizations connects to TEST_DATABASE_DSN (skips without it) and seeds benchOrganizations
+// organizations of a unique type with random params; everything is removed on cleanup.
+func seedBenchOrganizations(b *testing.B) string {
+	b.Helper()
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		b.Skip("TEST_DATABASE_DSN is not set")
+	}
+	if db.DB == nil {
+		db.Open(dsn)
+	}
+
+	orgType := fmt.Sprintf("bench_%d", time.Now().UnixNano())
+	owner := model.User{Name: "bench", Email: orgType + "@bench.local", Role: "admin"}
+	if err := db.DB.Create(&owner).Error; err != nil {
+		b.Fatal(err)
+	}
+	// организации и их параметры удаляются каскадно вместе с владельцем
+	b.Cleanup(func() { db.DB.Unscoped().Delete(&owner) })
+
+	rng := rand.New(rand.NewSource(1))
+	orgs := make([]model.Organization, benchOrganizations)
+	for i := range orgs {
+		orgs[i] = model.Organization{
+			OwnerID:          owner.ID,
+			Name:             fmt.Sprintf("Bench %d", i),
+			Address:          fmt.Sprintf("улица Тестовая %d", i),
+			OrganizationType: orgType,
+			Status:           model.OrganizationStatusActive,
+		}
+	}
+	if err := db.DB.CreateInBatches(&orgs, 500).Error; err != nil {
+		b.Fatal(err)
+	}
+	avg := func() (float64, uint) {
+		if rng.Intn(5) == 0 {
+			return 0, 0 // часть факторов без оценок
+		}
+		return 1 + rng.Float64()*4, uint(1 + rng.Intn(50))
+	}
+	params := make([]model.OrganizationParams, len(orgs))
+	for i, o := range orgs {
+		p := model.OrganizationParams{OrganizationID: o.ID}
+		p.AppearanceAvg, p.AppearanceCount = avg()
+		p.LightingAvg, p.LightingCount = avg()
+		p.SmellAvg, p.SmellCount = avg()
+		p.TemperatureAvg, p.TemperatureCount = avg()
+		p.TactilityAvg, p.TactilityCount = avg()
+		p.SignageAvg, p.SignageCount = avg()
+		p.IntuitivenessAvg, p.IntuitivenessCount = avg()
+		p.StaffAttitudeAvg, p.StaffAttitudeCount = avg()
+		p.PeopleDensityAvg, p.PeopleDensityCount = avg()
+		p.SelfServiceAvg, p.SelfServiceCount = avg()
+		p.CalmnessAvg, p.CalmnessCount = avg()
+		params[i] = p
+	}
+	if err := db.DB.CreateInBatches(&params, 500).Error; err != nil {
+		b.Fatal(err)
+	}
+	if err := db.DB.Exec("ANALYZE organizations, organization_params").Error; err != nil {
+		b.Fatal(err)
+	}
+	return orgType
+}
+
+func BenchmarkSearchOrganizationAverages(b *testing.B) {
+	orgType := seedBenchOrganizations(b)
+	cases := []struct {
+		name   string
+		filter OrganizationAverageFilter
+	}{
+		{"all params", OrganizationAverageFilter{Params: model.ParamNames, Limit: 20}},
+		{"selected params with minimums", OrganizationAverageFilter{
+			Params:    []string{"smell", "lighting", "calmness"},
+			Threshold: 2.5,
+			MinParams: map[string]float64{"smell": 3, "people_density": 2},
+			Limit:     20,
+		}},
+		{"reviews sort deep page", OrganizationAverageFilter{Params: []string{"calmness"}, Sort: "reviews_desc", Limit: 50, Offset: 1000}},
+		{"sensitivity aggregate", OrganizationAverageFilter{Params: model.ParamNames, Aggregate: model.AggregateSensitivity, Limit: 20}},
+	}
+	for _, tc := range cases {
+		tc.filter.OrganizationType = orgType
+		b.Run(tc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := SearchOrganizationAverages(tc.filter); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
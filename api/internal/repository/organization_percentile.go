@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"fmt"
+
+	"2gis-calm-map/api/internal/db"
+	"2gis-calm-map/api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// RefreshPercentiles recomputes percentiles of given params for all organizations of one type.
+// Archived and deleted organizations are not peers and lose their percentiles.
+// Params must be canonical names (see model.ParamNames). Concurrent refreshes of the same type
+// are serialized by an advisory lock held until the end of the transaction.
+func RefreshPercentiles(orgType string, params []string) error {
+	return db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "organization_percentiles:"+orgType).Error; err != nil {
+			return err
+		}
+		for _, name := range params {
+			if _, ok := model.NormalizeParamName(name); !ok {
+				return fmt.Errorf("unknown param: %s", name)
+			}
+			peers := "FROM organizations o JOIN organization_params p ON p.organization_id = o.id " +
+				"WHERE o.organization_type = ? AND o.status = 'active' AND o.deleted_at IS NULL AND p." + name + "_avg > 0"
+			if err := tx.Exec(
+				"DELETE FROM organization_percentiles WHERE param = ? "+
+					"AND organization_id IN (SELECT id FROM organizations WHERE organization_type = ?) "+
+					"AND organization_id NOT IN (SELECT o.id "+peers+")",
+				name, orgType, orgType,
+			).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(
+				"INSERT INTO organization_percentiles (organization_id, param, percentile, peer_count) "+
+					"SELECT o.id, ?, 100 * cume_dist() OVER (ORDER BY p."+name+"_avg), COUNT(*) OVER () "+peers+" "+
+					"ON CONFLICT (organization_id, param) DO UPDATE SET percentile = EXCLUDED.percentile, peer_count = EXCLUDED.peer_count",
+				name, orgType,
+			).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func ListOrganizationTypes() ([]string, error) {
+	var types []string
+	err := db.DB.Model(&model.Organization{}).Distinct().Pluck("organization_type", &types).Error
+	return types, err
+}
+
+func ListPercentiles(orgIDs []uint) ([]model.OrganizationPercentile, error) {
+	var list []model.OrganizationPercentile
+	if len(orgIDs) == 0 {
+		return list, nil
+	}
+	err := db.DB.Where("organization_id IN ?", orgIDs).Find(&list).Error
+	return list, err
+}
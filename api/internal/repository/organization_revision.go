@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"2gis-calm-map/api/internal/db"
+	"2gis-calm-map/api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ListOrganizationRevisions returns revisions newest first.
+func ListOrganizationRevisions(orgID uint, limit, offset int) ([]model.OrganizationRevision, error) {
+	var list []model.OrganizationRevision
+	q := db.DB.Where("organization_id = ?", orgID).Order("created_at DESC").Order("id DESC").Offset(offset)
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	err := q.Find(&list).Error
+	return list, err
+}
+
+func GetOrganizationRevision(orgID, id uint) (model.OrganizationRevision, error) {
+	var rev model.OrganizationRevision
+	err := db.DB.Where("organization_id = ?", orgID).First(&rev, id).Error
+	return rev, err
+}
+
+// Изменения организации и запись аудита пишутся в одной транзакции: либо сохраняется и то и другое, либо ничего.
+
+// CreateOrganizationWithRevision inserts the organization together with its "create" revision.
+func CreateOrganizationWithRevision(org *model.Organization, actorID uint) error {
+	return db.DB.Transaction(func(tx *gorm.DB) error {
+		return createOrganizationWithRevision(tx, org, actorID)
+	})
+}
+
+// CreateOrganizationsWithRevisions inserts all organizations with their revisions (nothing is created if any insert fails).
+func CreateOrganizationsWithRevisions(orgs []*model.Organization, actorID uint) error {
+	return db.DB.Transaction(func(tx *gorm.DB) error {
+		for _, org := range orgs {
+			if err := createOrganizationWithRevision(tx, org, actorID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func createOrganizationWithRevision(tx *gorm.DB, org *model.Organization, actorID uint) error {
+	if org.Status == "" {
+		org.Status = model.OrganizationStatusActive
+	}
+	if err := tx.Create(org).Error; err != nil {
+		return err
+	}
+	return tx.Create(&model.OrganizationRevision{
+		OrganizationID: org.ID,
+		ActorID:        actorID,
+		Action:         model.RevisionActionCreate,
+		After:          model.SnapshotOf(*org),
+	}).Error
+}
+
+// UpdateOrganizationWithRevision applies column updates and appends rev (OrganizationID and, if nil, After
+// are filled from the updated row). Returns the updated organization with params.
+func UpdateOrganizationWithRevision(id uint, updates map[string]interface{}, rev *model.OrganizationRevision) (model.Organization, error) {
+	var org model.Organization
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if len(updates) > 0 {
+			if err := tx.Model(&model.Organization{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Preload("Params").First(&org, id).Error; err != nil {
+			return err
+		}
+		rev.OrganizationID = id
+		if rev.After == nil {
+			rev.After = model.SnapshotOf(org)
+		}
+		return tx.Create(rev).Error
+	})
+	return org, err
+}
+
+// SoftDeleteOrganization sets deleted_at (comments, params and other dependent rows are kept) and appends rev.
+func SoftDeleteOrganization(id uint, rev *model.OrganizationRevision) error {
+	return db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.Organization{}, id).Error; err != nil {
+			return err
+		}
+		rev.OrganizationID = id
+		return tx.Create(rev).Error
+	})
+}
+
+// RestoreOrganization undoes SoftDeleteOrganization and appends rev.
+func RestoreOrganization(id uint, rev *model.OrganizationRevision) error {
+	return db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&model.Organization{}).Where("id = ?", id).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		rev.OrganizationID = id
+		return tx.Create(rev).Error
+	})
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"2gis-calm-map/api/internal/db"
+	"2gis-calm-map/api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// OrganizationSearchQuery is a full-text search over organizations.search_vector with optional filters.
+// Params and MinParams keys must be canonical names (see model.ParamNames).
+type OrganizationSearchQuery struct {
+	Text             string
+	OrganizationType string
+	Params           []string           // если заданы — в ответе среднее по ним
+	MinParams        map[string]float64 // per-factor minimum (avg >= value)
+	Aggregate        string
+	Latitude         *float64
+	Longitude        *float64
+	Radius           float64 // метры, 0 = без ограничения
+	OpenAt           *time.Time
+	QuietAt          *time.Time // если задано — только в «тихие часы» в этот момент
+	IncludeArchived  bool       // по умолчанию только status = active
+	BrandID          *uint
+	Amenities        []string // обязательные удобства (slugs)
+	Limit            int
+	Offset           int
+}
+
+// OrganizationSearchRow is a match with its text rank and (if Params were given) selected average.
+type OrganizationSearchRow struct {
+	model.Organization
+	Rank    float64
+	Average float64
+}
+
+// SearchTsQuery turns free text into a to_tsquery expression: words are AND-ed, the last one is a prefix
+// (autocomplete). Only letters and digits are kept, so user input cannot inject tsquery operators.
+// Returns "" if there is nothing to search for.
+func SearchTsQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+	words[len(words)-1] += ":*"
+	return strings.Join(words, " & ")
+}
+
+// SearchOrganizations ranks organizations matching text (russian and simple configurations) by ts_rank_cd.
+// search_vector is maintained by a trigger (see db.setupOrganizationSearch) and covered by a GIN index.
+func SearchOrganizations(q OrganizationSearchQuery) ([]OrganizationSearchRow, error) {
+	tsq := SearchTsQuery(q.Text)
+	if tsq == "" {
+		return []OrganizationSearchRow{}, nil
+	}
+	suffix := model.AggregateColumnSuffix(q.Aggregate)
+	columns := []string{"organizations.*", "ts_rank_cd(organizations.search_vector, fts.q) AS rank"}
+	if len(q.Params) > 0 {
+		columns = append(columns, selectedAverageExpr(q.Params, suffix)+" AS average")
+	}
+
+	tx := db.DB.Model(&model.Organization{}).
+		Select(columns).
+		Joins("Params").
+		Joins("CROSS JOIN (SELECT to_tsquery('russian'::regconfig, ?) || to_tsquery('simple'::regconfig, ?) AS q) fts", tsq, tsq).
+		Where("organizations.search_vector @@ fts.q")
+	tx = whereSearchFilters(tx, q, suffix)
+	tx = tx.Order("rank DESC").Order("organizations.id ASC")
+	if q.Limit > 0 {
+		tx = tx.Limit(q.Limit)
+	}
+	if q.Offset > 0 {
+		tx = tx.Offset(q.Offset)
+	}
+
+	var rows []OrganizationSearchRow
+	err := tx.Find(&rows).Error
+	return rows, err
+}
+
+// whereSearchFilters applies the non-text filters of q (type, per-factor minimums, radius, status, brand,
+// amenities, open/quiet moment); the query must join "Params".
+func whereSearchFilters(tx *gorm.DB, q OrganizationSearchQuery, suffix string) *gorm.DB {
+	if q.OrganizationType != "" {
+		tx = tx.Where("organizations.organization_type = ?", q.OrganizationType)
+	}
+	names := make([]string, 0, len(q.MinParams))
+	for name := range q.MinParams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		tx = tx.Where(`COALESCE("Params".`+name+suffix+", 0) >= ?", q.MinParams[name])
+	}
+	if q.Radius > 0 && q.Latitude != nil && q.Longitude != nil {
+		tx = whereWithinRadius(tx, "organizations.latitude", "organizations.longitude", *q.Latitude, *q.Longitude, q.Radius)
+	}
+	if !q.IncludeArchived {
+		tx = tx.Where(activeOrganizationSQL)
+	}
+	if q.BrandID != nil {
+		tx = tx.Where("organizations.brand_id = ?", *q.BrandID)
+	}
+	tx = whereAmenities(tx, q.Amenities)
+	if q.OpenAt != nil {
+		tx = whereOpenAt(tx, *q.OpenAt)
+	}
+	if q.QuietAt != nil {
+		tx = whereQuietAt(tx, *q.QuietAt)
+	}
+	return tx
+}
+
+// StreamOrganizations passes organizations with coordinates matching q (Text is optional here; Params, Limit and
+// Offset are ignored) to fn in id order. Rows are loaded batchSize at a time by keyset pagination,
+// so the whole result is never held in memory.
+func StreamOrganizations(q OrganizationSearchQuery, batchSize int, fn func(model.Organization) error) error {
+	suffix := model.AggregateColumnSuffix(q.Aggregate)
+	tsq := SearchTsQuery(q.Text)
+	var lastID uint
+	for {
+		tx := db.DB.Model(&model.Organization{}).
+			Joins("Params").
+			Where("organizations.latitude IS NOT NULL AND organizations.longitude IS NOT NULL").
+			Where("organizations.id > ?", lastID)
+		if tsq != "" {
+			tx = tx.Where("organizations.search_vector @@ (to_tsquery('russian'::regconfig, ?) || to_tsquery('simple'::regconfig, ?))", tsq, tsq)
+		}
+		tx = whereSearchFilters(tx, q, suffix)
+
+		var batch []model.Organization
+		if err := tx.Order("organizations.id ASC").Limit(batchSize).Find(&batch).Error; err != nil {
+			return err
+		}
+		for _, org := range batch {
+			if err := fn(org); err != nil {
+				return err
+			}
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+		lastID = batch[len(batch)-1].ID
+	}
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"2gis-calm-map/api/internal/db"
+	"2gis-calm-map/api/internal/model"
+
+	"gorm.io/gorm/clause"
+)
+
+// SeedOrganizationTypes inserts missing taxonomy entries; existing rows are not modified.
+// Returns the number of inserted entries.
+func SeedOrganizationTypes(types []model.OrganizationType) (int64, error) {
+	if len(types) == 0 {
+		return 0, nil
+	}
+	res := db.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&types)
+	return res.RowsAffected, res.Error
+}
+
+// FindOrganizationType looks a type up by slug or by localized name (case-insensitive).
+func FindOrganizationType(slug, name string) (model.OrganizationType, error) {
+	var t model.OrganizationType
+	err := db.DB.Where("slug = ? OR lower(name_ru) = lower(?) OR lower(name_en) = lower(?)", slug, name, name).
+		Order("slug ASC").First(&t).Error
+	return t, err
+}
+
+// OrganizationTypeCount is a taxonomy entry with the number of organizations of this type.
+type OrganizationTypeCount struct {
+	model.OrganizationType
+	OrganizationCount int64 `json:"organization_count"`
+}
+
+func ListOrganizationTypesWithCounts() ([]OrganizationTypeCount, error) {
+	var list []OrganizationTypeCount
+	err := db.DB.Model(&model.OrganizationType{}).
+		Select("organization_types.*, (SELECT COUNT(*) FROM organizations o WHERE o.organization_type = organization_types.slug AND o.status = 'active' AND o.deleted_at IS NULL) AS organization_count").
+		Order("organization_types.slug ASC").
+		Find(&list).Error
+	return list, err
+}
+
+// ListUnknownOrganizationTypes returns organization_type values that are not slugs of the taxonomy
+// (soft-deleted organizations included, so a restored one does not bring a legacy value back).
+func ListUnknownOrganizationTypes() ([]string, error) {
+	var types []string
+	err := db.DB.Unscoped().Model(&model.Organization{}).
+		Where("organization_type NOT IN (SELECT slug FROM organization_types)").
+		Distinct().Pluck("organization_type", &types).Error
+	return types, err
+}
+
+// RefreshOrganizationSearchVectors rewrites search_vector of all organizations (the trigger picks up type names).
+func RefreshOrganizationSearchVectors() error {
+	return db.DB.Exec("UPDATE organizations SET name = name").Error
+}
+
+// RenameOrganizationsType moves all organizations, soft-deleted included, from one type value to another.
+func RenameOrganizationsType(from, to string) error {
+	return db.DB.Unscoped().Model(&model.Organization{}).Where("organization_type = ?", from).Update("organization_type", to).Error
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"2gis-calm-map/api/internal/db"
+	"2gis-calm-map/api/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UpsertOrganizationVector inserts or replaces precomputed vector of an organization.
+func UpsertOrganizationVector(v *model.OrganizationVector) error {
+	return db.DB.Clauses(clause.OnConflict{UpdateAll: true}).Create(v).Error
+}
+
+func GetOrganizationVector(orgID uint) (model.OrganizationVector, error) {
+	var v model.OrganizationVector
+	err := db.DB.Where("organization_id = ?", orgID).First(&v).Error
+	return v, err
+}
+
+// ListOrganizationParamsWithoutVector returns aggregates that have no precomputed vector yet (startup backfill).
+func ListOrganizationParamsWithoutVector() ([]model.OrganizationParams, error) {
+	var list []model.OrganizationParams
+	err := db.DB.Where("organization_id NOT IN (SELECT organization_id FROM organization_vectors)").Find(&list).Error
+	return list, err
+}
+
+// SimilarQuery describes nearest-neighbour search over organization_vectors.
+type SimilarQuery struct {
+	Source    model.OrganizationVector
+	Metric    string // euclidean | cosine
+	MinRated  uint
+	SameType  string   // если не пусто — только этот тип
+	Latitude  *float64 // центр для Radius
+	Longitude *float64
+	Radius    float64    // метры, 0 = без ограничения
+	OpenAt    *time.Time // если задано — только открытые в этот момент
+	QuietAt   *time.Time // если задано — только в «тихие часы» в этот момент
+	Limit     int
+}
+
+// SimilarRow is a candidate organization with its vector distance/similarity.
+type SimilarRow struct {
+	model.Organization
+	Distance   float64
+	Similarity float64
+}
+
+// FindSimilarOrganizations ranks organizations by closeness of their vectors to the source one.
+// With db.VectorIndex the ranking is a KNN scan of the GiST index over organization_vectors.embedding
+// (euclidean) or .direction (unit vectors: euclidean order = cosine order), so a request reads about
+// Limit rows plus those rejected by filters instead of scoring the whole catalog. Without the cube
+// extension every vector is scored and sorted (type and radius filters still narrow the scan).
+func FindSimilarOrganizations(q SimilarQuery) ([]SimilarRow, error) {
+	if db.VectorIndex {
+		return findSimilarByIndex(q)
+	}
+	src := q.Source.Values()
+	terms := make([]string, len(model.ParamNames))
+	dot := make([]string, len(model.ParamNames))
+	var args []interface{}
+	for i, name := range model.ParamNames {
+		terms[i] = "power(v." + name + " - ?, 2)"
+		dot[i] = "v." + name + " * ?"
+		args = append(args, src[i])
+	}
+	distExpr := "sqrt(" + strings.Join(terms, " + ") + ")"
+	dotExpr := "(" + strings.Join(dot, " + ") + ")"
+
+	// similarity в [0,1]: для евклидовой — 1 - d/max(d) (max = 2*sqrt(11)); для косинусной — (cos+1)/2
+	selectSQL := "organizations.*, " + distExpr + " AS distance, "
+	selectArgs := append([]interface{}{}, args...)
+	order := "distance ASC"
+	if q.Metric == "cosine" {
+		selectSQL += "(" + dotExpr + " / NULLIF(v.norm * ?, 0) + 1) / 2 AS similarity"
+		selectArgs = append(selectArgs, args...)
+		selectArgs = append(selectArgs, q.Source.Norm)
+		order = "similarity DESC"
+	} else {
+		selectSQL += "1 - " + distExpr + " / (2 * sqrt(11)) AS similarity"
+		selectArgs = append(selectArgs, args...)
+	}
+
+	tx := db.DB.Model(&model.Organization{}).
+		Select(selectSQL, selectArgs...).
+		Joins("JOIN organization_vectors v ON v.organization_id = organizations.id").
+		Preload("Params") // Joins("Params") не добавляет свои колонки при Select с аргументами
+	tx = whereSimilarFilters(tx, q)
+
+	var rows []SimilarRow
+	err := tx.Order(order).Order("organizations.id ASC").Limit(q.Limit).Find(&rows).Error
+	return rows, err
+}
+
+// findSimilarByIndex orders by the cube distance operator so PostgreSQL walks the GiST index nearest-first.
+// similarity: euclidean — 1 - d/(2*sqrt(11)); cosine — (cos+1)/2 = 1 - d²/4, d between unit vectors.
+func findSimilarByIndex(q SimilarQuery) ([]SimilarRow, error) {
+	point := func(vals []float64) string {
+		parts := make([]string, len(vals))
+		for i, v := range vals {
+			parts[i] = strconv.FormatFloat(v, 'g', -1, 64)
+		}
+		return "(" + strings.Join(parts, ", ") + ")"
+	}
+	src := q.Source.Values()
+	embedding := point(src)
+
+	selectSQL := "organizations.*, v.embedding <-> CAST(? AS cube) AS distance, "
+	selectArgs := []interface{}{embedding}
+	orderSQL := "v.embedding <-> CAST(? AS cube)"
+	orderArg := embedding
+	if q.Metric == "cosine" {
+		unit := make([]float64, len(src))
+		for i, v := range src {
+			if q.Source.Norm > 0 {
+				unit[i] = v / q.Source.Norm
+			}
+		}
+		direction := point(unit)
+		selectSQL += "1 - power(v.direction <-> CAST(? AS cube), 2) / 4 AS similarity"
+		selectArgs = append(selectArgs, direction)
+		orderSQL, orderArg = "v.direction <-> CAST(? AS cube)", direction
+	} else {
+		selectSQL += "1 - (v.embedding <-> CAST(? AS cube)) / (2 * sqrt(11)) AS similarity"
+		selectArgs = append(selectArgs, embedding)
+	}
+
+	tx := db.DB.Model(&model.Organization{}).
+		Select(selectSQL, selectArgs...).
+		Joins("JOIN organization_vectors v ON v.organization_id = organizations.id").
+		Preload("Params")
+	tx = whereSimilarFilters(tx, q)
+
+	var rows []SimilarRow
+	// одним выражением: OrderBy с Expression не сливается с последующим Order(...)
+	err := tx.Order(clause.Expr{SQL: orderSQL + ", organizations.id ASC", Vars: []interface{}{orderArg}}).
+		Limit(q.Limit).Find(&rows).Error
+	return rows, err
+}
+
+// whereSimilarFilters excludes the source and applies status, rated count, type, radius and schedule filters.
+func whereSimilarFilters(tx *gorm.DB, q SimilarQuery) *gorm.DB {
+	tx = tx.Where("organizations.id <> ?", q.Source.OrganizationID).
+		Where(activeOrganizationSQL).
+		Where("v.rated_count >= ?", q.MinRated)
+	if q.Metric == "cosine" {
+		tx = tx.Where("v.norm > 0")
+	}
+	if q.SameType != "" {
+		tx = tx.Where("organizations.organization_type = ?", q.SameType)
+	}
+	if q.Radius > 0 && q.Latitude != nil && q.Longitude != nil {
+		tx = whereWithinRadius(tx, "organizations.latitude", "organizations.longitude", *q.Latitude, *q.Longitude, q.Radius)
+	}
+	if q.OpenAt != nil {
+		tx = whereOpenAt(tx, *q.OpenAt)
+	}
+	if q.QuietAt != nil {
+		tx = whereQuietAt(tx, *q.QuietAt)
+	}
+	return tx
+}
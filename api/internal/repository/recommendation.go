@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"2gis-calm-map/api/internal/db"
+	"2gis-calm-map/api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// UserOrganizationRating is the mean of a user's comment averages for one organization.
+type UserOrganizationRating struct {
+	UserID         uint
+	OrganizationID uint
+	Rating         float64
+}
+
+func ListAllUserParams() ([]model.UserParams, error) {
+	var list []model.UserParams
+	err := db.DB.Find(&list).Error
+	return list, err
+}
+
+// ListUserOrganizationRatings aggregates comments to one rating per (user, organization).
+func ListUserOrganizationRatings() ([]UserOrganizationRating, error) {
+	var list []UserOrganizationRating
+	err := db.DB.Model(&model.OrganizationComment{}).
+		Select("user_id, organization_id, AVG(avg_value) AS rating").
+		Where("avg_value IS NOT NULL").
+		Group("user_id, organization_id").
+		Scan(&list).Error
+	return list, err
+}
+
+// ReplaceUserRecommendations swaps the whole recommendations table contents in one transaction.
+func ReplaceUserRecommendations(list []model.UserRecommendation) error {
+	return db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&model.UserRecommendation{}).Error; err != nil {
+			return err
+		}
+		if len(list) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(&list, 500).Error
+	})
+}
+
+func ListUserRecommendations(userID uint, limit int) ([]model.UserRecommendation, error) {
+	var list []model.UserRecommendation
+	// рекомендации пересобираются периодически — закрытые с тех пор организации отсекаем при чтении
+	err := db.DB.Preload("Organization").
+		Joins("JOIN organizations ON organizations.id = user_recommendations.organization_id AND "+activeOrganizationSQL).
+		Where("user_recommendations.user_id = ?", userID).
+		Order("user_recommendations.score DESC").Order("user_recommendations.supporters DESC").
+		Limit(limit).Find(&list).Error
+	return list, err
+}
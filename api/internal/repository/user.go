@@ -22,3 +22,15 @@ func GetUserByEmail(email string) (model.User, error) {
 	err := db.DB.Where("email = ?", email).First(&user).Error
 	return user, err
 }
+
+func GetUserByID(id uint) (model.User, error) {
+	var u model.User
+	err := db.DB.First(&u, id).Error
+	return u, err
+}
+
+func ListUserIDsByRole(role string) ([]uint, error) {
+	var ids []uint
+	err := db.DB.Model(&model.User{}).Where("role = ?", role).Pluck("id", &ids).Error
+	return ids, err
+}
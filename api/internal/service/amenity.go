@@ -0,0 +1,122 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrUnknownAmenity is returned for slugs missing from the catalog.
+	ErrUnknownAmenity = errors.New("unknown amenity")
+	// ErrAmenityNotDeclared is returned when voting on an amenity the organization does not declare.
+	ErrAmenityNotDeclared = errors.New("amenity is not declared by the organization")
+	// ErrAmenityVoteWithoutReview is returned when the voter has not reviewed the organization.
+	ErrAmenityVoteWithoutReview = errors.New("only visitors who reviewed the organization can vote on its amenities")
+)
+
+type AmenityService struct{}
+
+func NewAmenityService() *AmenityService { return &AmenityService{} }
+
+// Seed inserts the default catalog.
+func (s *AmenityService) Seed() error {
+	return repository.SeedAmenities(model.DefaultAmenities)
+}
+
+func (s *AmenityService) Catalog() ([]model.Amenity, error) {
+	return repository.ListAmenities()
+}
+
+// Normalize lowercases, dedupes and sorts slugs and checks them against the catalog.
+func (s *AmenityService) Normalize(raw []string) ([]string, error) {
+	seen := map[string]bool{}
+	slugs := make([]string, 0, len(raw))
+	for _, r := range raw {
+		slug := strings.ToLower(strings.TrimSpace(r))
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+	if len(slugs) == 0 {
+		return slugs, nil
+	}
+	n, err := repository.CountAmenities(slugs)
+	if err != nil {
+		return nil, err
+	}
+	if n != int64(len(slugs)) {
+		return nil, ErrUnknownAmenity
+	}
+	return slugs, nil
+}
+
+// OrganizationAmenityView is a declared amenity with vote counters and the caller's own vote.
+type OrganizationAmenityView struct {
+	model.OrganizationAmenity
+	MyVote *bool
+}
+
+// ForOrganization lists declared amenities; userID 0 means anonymous (no MyVote).
+func (s *AmenityService) ForOrganization(orgID, userID uint) ([]OrganizationAmenityView, error) {
+	list, err := repository.ListOrganizationAmenities(orgID)
+	if err != nil {
+		return nil, err
+	}
+	votes := map[string]bool{}
+	if userID != 0 {
+		if votes, err = repository.ListUserAmenityVotes(orgID, userID); err != nil {
+			return nil, err
+		}
+	}
+	views := make([]OrganizationAmenityView, 0, len(list))
+	for _, a := range list {
+		v := OrganizationAmenityView{OrganizationAmenity: a}
+		if confirmed, ok := votes[a.AmenitySlug]; ok {
+			v.MyVote = &confirmed
+		}
+		views = append(views, v)
+	}
+	return views, nil
+}
+
+// Declare replaces the organization's declared amenities.
+func (s *AmenityService) Declare(orgID, actorID uint, raw []string) ([]OrganizationAmenityView, error) {
+	slugs, err := s.Normalize(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := repository.ReplaceOrganizationAmenities(orgID, actorID, slugs); err != nil {
+		return nil, err
+	}
+	return s.ForOrganization(orgID, actorID)
+}
+
+// Vote confirms or disputes a declared amenity; only users who reviewed the organization may vote,
+// a repeated vote replaces the previous one.
+func (s *AmenityService) Vote(orgID uint, slug string, userID uint, confirmed bool) (model.OrganizationAmenity, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if _, err := repository.GetOrganizationAmenity(orgID, slug); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.OrganizationAmenity{}, ErrAmenityNotDeclared
+		}
+		return model.OrganizationAmenity{}, err
+	}
+	// голосуют только посетители, оставившие отзыв: иначе подтверждения легко накрутить
+	reviewed, err := repository.HasUserCommented(orgID, userID)
+	if err != nil {
+		return model.OrganizationAmenity{}, err
+	}
+	if !reviewed {
+		return model.OrganizationAmenity{}, ErrAmenityVoteWithoutReview
+	}
+	return repository.UpsertAmenityVote(&model.AmenityVote{OrganizationID: orgID, AmenitySlug: slug, UserID: userID, Confirmed: confirmed})
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"errors"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/repository"
+)
+
+// ErrOrganizationInOtherBrand is returned when attaching a branch that already belongs to another brand.
+var ErrOrganizationInOtherBrand = errors.New("organization already belongs to another brand")
+
+type BrandService struct{}
+
+func NewBrandService() *BrandService { return &BrandService{} }
+
+// BrandDetail is a brand with its active branches and factor scores aggregated over them.
+type BrandDetail struct {
+	Brand    model.Brand
+	Branches []model.Organization
+	Factors  []repository.FactorAggregate
+	Average  float64 // среднее по факторам, у которых есть оценки
+}
+
+func (s *BrandService) Create(b *model.Brand) error {
+	return repository.CreateBrand(b)
+}
+
+func (s *BrandService) Get(id uint) (model.Brand, error) {
+	return repository.GetBrand(id)
+}
+
+func (s *BrandService) Update(id uint, updates map[string]interface{}) (model.Brand, error) {
+	return repository.UpdateBrand(id, updates)
+}
+
+// List returns brands with active branch counts; ownerID 0 means all owners.
+func (s *BrandService) List(ownerID uint, limit, offset int) ([]repository.BrandWithCount, error) {
+	return repository.ListBrands(ownerID, limit, offset)
+}
+
+func (s *BrandService) Detail(id uint) (BrandDetail, error) {
+	brand, err := repository.GetBrand(id)
+	if err != nil {
+		return BrandDetail{}, err
+	}
+	branches, err := repository.ListBrandOrganizations(id, false)
+	if err != nil {
+		return BrandDetail{}, err
+	}
+	factors, err := repository.BrandFactors(id)
+	if err != nil {
+		return BrandDetail{}, err
+	}
+	d := BrandDetail{Brand: brand, Branches: branches, Factors: factors}
+	var sum float64
+	var n int
+	for _, f := range factors {
+		if f.Count > 0 {
+			sum += f.Average
+			n++
+		}
+	}
+	if n > 0 {
+		d.Average = sum / float64(n)
+	}
+	return d, nil
+}
+
+// Manages reports whether the user owns the brand (nil brand — false).
+func (s *BrandService) Manages(userID uint, brandID *uint) bool {
+	if brandID == nil {
+		return false
+	}
+	b, err := repository.GetBrand(*brandID)
+	return err == nil && b.OwnerID == userID
+}
+
+// Attach makes the organization a branch of the brand (recorded in organization history).
+func (s *BrandService) Attach(brandID, orgID, actorID uint) (model.Organization, error) {
+	org, err := repository.GetOrganizationByID(orgID)
+	if err != nil {
+		return org, err
+	}
+	if org.BrandID != nil {
+		if *org.BrandID == brandID {
+			return org, nil
+		}
+		return org, ErrOrganizationInOtherBrand
+	}
+	return setBrand(org, &brandID, actorID)
+}
+
+// Detach removes the organization from its brand.
+func (s *BrandService) Detach(orgID, actorID uint) (model.Organization, error) {
+	org, err := repository.GetOrganizationByID(orgID)
+	if err != nil || org.BrandID == nil {
+		return org, err
+	}
+	return setBrand(org, nil, actorID)
+}
+
+func setBrand(org model.Organization, brandID *uint, actorID uint) (model.Organization, error) {
+	return repository.UpdateOrganizationWithRevision(org.ID, map[string]interface{}{"brand_id": brandID}, &model.OrganizationRevision{
+		ActorID: actorID,
+		Action:  model.RevisionActionUpdate,
+		Before:  model.SnapshotOf(org),
+	})
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"2gis-calm-map/api/internal/model"
+)
+
+// Geocoder resolves an address to coordinates; ok=false means the address is unknown to it.
+type Geocoder interface {
+	Geocode(address string) (lat, lon float64, ok bool, err error)
+}
+
+// geocoder is used to fill missing coordinates; nil disables geocoding (see SetGeocoder).
+var geocoder Geocoder
+
+// SetGeocoder installs the geocoder used on organization create/patch and by coordinate backfill.
+func SetGeocoder(g Geocoder) { geocoder = g }
+
+type geoPoint struct{ lat, lon float64 }
+
+// GazetteerGeocoder is an offline geocoder over a locally loaded address list (e.g. an OSM extract).
+// Addresses are matched by model.NormalizeAddress, so abbreviations and punctuation do not matter.
+// A normalized address that refers to different places (e.g. the same street and house in two cities
+// when the city is omitted) is ambiguous and never resolved.
+type GazetteerGeocoder struct {
+	points    map[string]geoPoint
+	ambiguous map[string]bool
+}
+
+// gazetteerSamePlaceMeters: duplicate rows closer than this are one building (entrances), not a collision.
+const gazetteerSamePlaceMeters = 150
+
+// gazetteer CSV columns (header is required, names are case-insensitive; "," or ";" separated):
+// either address, or city/street/housenumber (OSM addr:* names are accepted too), plus lat and lon.
+var (
+	gazetteerAddressColumns = []string{"address", "addr:full", "full_address"}
+	gazetteerCityColumns    = []string{"city", "addr:city"}
+	gazetteerStreetColumns  = []string{"street", "addr:street"}
+	gazetteerHouseColumns   = []string{"housenumber", "house", "addr:housenumber"}
+	gazetteerLatColumns     = []string{"lat", "latitude", "y"}
+	gazetteerLonColumns     = []string{"lon", "lng", "longitude", "x"}
+)
+
+// LoadGazetteer reads a gazetteer CSV file.
+func LoadGazetteer(path string) (*GazetteerGeocoder, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadGazetteer(f)
+}
+
+// ReadGazetteer parses gazetteer CSV (see gazetteer* columns); rows with invalid coordinates are skipped.
+func ReadGazetteer(r io.Reader) (*GazetteerGeocoder, error) {
+	cr, find, err := readCSVHeader(r)
+	if err != nil {
+		return nil, fmt.Errorf("gazetteer header: %w", err)
+	}
+	addrCol, cityCol, streetCol, houseCol := find(gazetteerAddressColumns), find(gazetteerCityColumns), find(gazetteerStreetColumns), find(gazetteerHouseColumns)
+	latCol, lonCol := find(gazetteerLatColumns), find(gazetteerLonColumns)
+	if latCol < 0 || lonCol < 0 || (addrCol < 0 && (streetCol < 0 || houseCol < 0)) {
+		return nil, errors.New("gazetteer header must contain lat, lon and address (or street and housenumber)")
+	}
+	get := func(rec []string, i int) string {
+		if i < 0 || i >= len(rec) {
+			return ""
+		}
+		return strings.TrimSpace(rec[i])
+	}
+
+	g := &GazetteerGeocoder{points: map[string]geoPoint{}, ambiguous: map[string]bool{}}
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		lat, errLat := strconv.ParseFloat(get(rec, latCol), 64)
+		lon, errLon := strconv.ParseFloat(get(rec, lonCol), 64)
+		if errLat != nil || errLon != nil || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+			continue
+		}
+		p := geoPoint{lat: lat, lon: lon}
+		if addr := get(rec, addrCol); addr != "" {
+			g.add(addr, p)
+		}
+		if street, house := get(rec, streetCol), get(rec, houseCol); street != "" && house != "" {
+			g.add(street+" "+house, p)
+			if city := get(rec, cityCol); city != "" {
+				g.add(city+" "+street+" "+house, p)
+			}
+		}
+	}
+	return g, nil
+}
+
+// add keeps the first point for a normalized address when duplicates are close to it (entrances of one building);
+// a duplicate elsewhere makes the address ambiguous.
+func (g *GazetteerGeocoder) add(address string, p geoPoint) {
+	key := model.NormalizeAddress(address)
+	if key == "" || g.ambiguous[key] {
+		return
+	}
+	prev, ok := g.points[key]
+	if !ok {
+		g.points[key] = p
+		return
+	}
+	if distanceMeters(prev.lat, prev.lon, p.lat, p.lon) > gazetteerSamePlaceMeters {
+		delete(g.points, key)
+		g.ambiguous[key] = true
+	}
+}
+
+// Len returns the number of indexed (unambiguous) addresses.
+func (g *GazetteerGeocoder) Len() int { return len(g.points) }
+
+// Geocode looks the normalized address up; if it is unknown, leading tokens (city, region) are dropped one by one.
+// Reaching an ambiguous address stops the lookup: shorter suffixes are at least as ambiguous.
+func (g *GazetteerGeocoder) Geocode(address string) (float64, float64, bool, error) {
+	tokens := strings.Fields(model.NormalizeAddress(address))
+	for len(tokens) >= 2 {
+		key := strings.Join(tokens, " ")
+		if p, ok := g.points[key]; ok {
+			return p.lat, p.lon, true, nil
+		}
+		if g.ambiguous[key] {
+			break
+		}
+		tokens = tokens[1:]
+	}
+	return 0, 0, false, nil
+}
+
+// readCSVHeader detects the separator ("," or ";") by the first line, reads the header and returns
+// the reader positioned at the first record and a lookup of the first present column among names (-1 if none).
+func readCSVHeader(r io.Reader) (*csv.Reader, func(names []string) int, error) {
+	br := bufio.NewReader(r)
+	first, err := br.Peek(4096)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, nil, err
+	}
+	header := string(first)
+	if i := strings.IndexByte(header, '\n'); i >= 0 {
+		header = header[:i]
+	}
+	cr := csv.NewReader(br)
+	if strings.Count(header, ";") > strings.Count(header, ",") {
+		cr.Comma = ';'
+	}
+	cr.FieldsPerRecord = -1
+
+	cols, err := cr.Read()
+	if err != nil {
+		return nil, nil, err
+	}
+	index := map[string]int{}
+	for i, c := range cols {
+		index[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(c, "\ufeff")))] = i
+	}
+	find := func(names []string) int {
+		for _, n := range names {
+			if i, ok := index[n]; ok {
+				return i
+			}
+		}
+		return -1
+	}
+	return cr, find, nil
+}
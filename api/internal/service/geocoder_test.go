@@ -0,0 +1,114 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadGazetteer(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		lookups map[string]*geoPoint // nil — address must not resolve
+		wantLen int
+	}{
+		{
+			name: "address column, comma separated",
+			csv: "address,lat,lon\n" +
+				"\"Москва, ул. Ленина, 5\",55.75,37.61\n" +
+				"\"Москва, пр-т Мира, 12/3\",55.78,37.63\n",
+			lookups: map[string]*geoPoint{
+				"г. Москва, улица Ленина 5":  {55.75, 37.61},
+				"Москва, проспект Мира 12/3": {55.78, 37.63},
+				"Казань, ул. Баумана, 1":     nil,
+			},
+			wantLen: 2,
+		},
+		{
+			name: "semicolon separated with BOM and case-insensitive OSM columns",
+			csv: "\ufeffADDR:CITY;addr:street;addr:housenumber;Latitude;Longitude\n" +
+				"Казань;улица Баумана;1;55.79;49.11\n",
+			lookups: map[string]*geoPoint{
+				"Казань, ул. Баумана, д. 1": {55.79, 49.11},
+				"ул. Баумана 1":             {55.79, 49.11},
+				// неизвестный регион перед адресом отбрасывается
+				"Татарстан, Казань, Баумана ул., 1": {55.79, 49.11},
+			},
+			wantLen: 2,
+		},
+		{
+			name: "rows with invalid coordinates are skipped",
+			csv: "address,lat,lon\n" +
+				"ул. Ленина 1,abc,37.6\n" +
+				"ул. Ленина 2,91,37.6\n" +
+				"ул. Ленина 3,55.7,37.6\n",
+			lookups: map[string]*geoPoint{
+				"ул. Ленина 1": nil,
+				"ул. Ленина 2": nil,
+				"ул. Ленина 3": {55.7, 37.6},
+			},
+			wantLen: 1,
+		},
+		{
+			name: "same street and house in two cities is ambiguous without the city",
+			csv: "city,street,housenumber,lat,lon\n" +
+				"Москва,ул. Ленина,5,55.75,37.61\n" +
+				"Казань,ул. Ленина,5,55.79,49.11\n",
+			lookups: map[string]*geoPoint{
+				"Москва, ул. Ленина, 5":   {55.75, 37.61},
+				"Казань, ул. Ленина, 5":   {55.79, 49.11},
+				"ул. Ленина, 5":           nil,
+				"Самара, ул. Ленина, 5":   nil,
+				"Москва, ул. Ленина, 6":   nil,
+				"ул. Ленина, 5, корпус 2": nil,
+			},
+			wantLen: 2,
+		},
+		{
+			name: "nearby duplicates (entrances) keep the first point",
+			csv: "address,lat,lon\n" +
+				"ул. Ленина 5,55.75000,37.61000\n" +
+				"ул. Ленина 5,55.75020,37.61030\n",
+			lookups: map[string]*geoPoint{
+				"улица Ленина, дом 5": {55.75, 37.61},
+			},
+			wantLen: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := ReadGazetteer(strings.NewReader(tt.csv))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if g.Len() != tt.wantLen {
+				t.Errorf("Len() = %d, want %d", g.Len(), tt.wantLen)
+			}
+			for addr, want := range tt.lookups {
+				lat, lon, ok, err := g.Geocode(addr)
+				if err != nil {
+					t.Fatal(err)
+				}
+				switch {
+				case want == nil && ok:
+					t.Errorf("Geocode(%q) = %v, %v; want not found", addr, lat, lon)
+				case want != nil && (!ok || lat != want.lat || lon != want.lon):
+					t.Errorf("Geocode(%q) = %v, %v, %v; want %v, %v", addr, lat, lon, ok, want.lat, want.lon)
+				}
+			}
+		})
+	}
+}
+
+func TestReadGazetteerHeader(t *testing.T) {
+	for _, csv := range []string{
+		"",
+		"name,lat,lon\nx,1,2\n",
+		"address,lat\nул. Ленина 5,55.7\n",
+		"street,lat,lon\nул. Ленина,55.7,37.6\n",
+	} {
+		if _, err := ReadGazetteer(strings.NewReader(csv)); err == nil {
+			t.Errorf("ReadGazetteer(%q): want error", csv)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/repository"
+)
+
+// LeaderboardPresets are common param combinations that get precomputed boards.
+var LeaderboardPresets = map[string][]string{
+	"overall":    model.ParamNames,
+	"quiet":      {"calmness", "people_density"},
+	"sensory":    {"lighting", "smell", "temperature", "tactility"},
+	"navigation": {"signage", "intuitiveness", "self_service"},
+	"staff":      {"staff_attitude"},
+}
+
+const (
+	leaderboardSize       = 20
+	leaderboardMinReviews = 1
+)
+
+// типы организаций, для которых агрегаты изменились после последней пересборки
+var leaderboardDirty = struct {
+	sync.Mutex
+	types map[string]bool
+}{types: map[string]bool{}}
+
+type LeaderboardService struct{}
+
+func NewLeaderboardService() *LeaderboardService { return &LeaderboardService{} }
+
+// MarkDirty schedules rebuild of all boards of a type on the next worker tick.
+func (s *LeaderboardService) MarkDirty(orgType string) {
+	leaderboardDirty.Lock()
+	leaderboardDirty.types[orgType] = true
+	leaderboardDirty.Unlock()
+}
+
+// RunWorker rebuilds everything once, then every interval rebuilds only dirty types. Blocks; run in a goroutine.
+func (s *LeaderboardService) RunWorker(interval time.Duration) {
+	if err := s.RebuildAll(); err != nil {
+		log.Println("warn: leaderboards rebuild failed:", err)
+	}
+	for {
+		time.Sleep(interval)
+		leaderboardDirty.Lock()
+		types := leaderboardDirty.types
+		leaderboardDirty.types = map[string]bool{}
+		leaderboardDirty.Unlock()
+		for t := range types {
+			if err := s.RebuildType(t); err != nil {
+				log.Println("warn: leaderboard rebuild failed for type", t, err)
+				s.MarkDirty(t) // попробуем на следующем тике
+			}
+		}
+	}
+}
+
+func (s *LeaderboardService) RebuildAll() error {
+	types, err := repository.ListOrganizationTypes()
+	if err != nil {
+		return err
+	}
+	for _, t := range types {
+		if err := s.RebuildType(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RebuildType recomputes every preset board of a type using the SQL by-type search.
+func (s *LeaderboardService) RebuildType(orgType string) error {
+	now := time.Now()
+	for preset, params := range LeaderboardPresets {
+		rows, err := repository.SearchOrganizationAverages(repository.OrganizationAverageFilter{
+			OrganizationType: orgType,
+			Params:           params,
+			Threshold:        0,
+			MinReviews:       leaderboardMinReviews,
+			Limit:            leaderboardSize,
+		})
+		if err != nil {
+			return err
+		}
+		entries := make([]model.LeaderboardEntry, 0, len(rows))
+		for i, r := range rows {
+			entries = append(entries, model.LeaderboardEntry{
+				OrganizationType: orgType,
+				Preset:           preset,
+				Rank:             uint(i + 1),
+				OrganizationID:   r.ID,
+				Average:          r.Average,
+				ReviewCount:      r.ReviewCount,
+				BuiltAt:          now,
+			})
+		}
+		if err := repository.ReplaceLeaderboard(orgType, "", preset, entries); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get returns stored boards of a type; empty preset means all presets.
+func (s *LeaderboardService) Get(orgType, preset string, limit int) ([]model.LeaderboardEntry, error) {
+	return repository.ListLeaderboard(orgType, "", preset, limit)
+}
+
+// PresetNames returns sorted preset names (for validation / docs).
+func (s *LeaderboardService) PresetNames() []string {
+	names := make([]string, 0, len(LeaderboardPresets))
+	for n := range LeaderboardPresets {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/repository"
+)
+
+type NotificationService struct{}
+
+func NewNotificationService() *NotificationService { return &NotificationService{} }
+
+// Notify stores notifications; failures are logged, the triggering action is not rolled back.
+func (s *NotificationService) Notify(list ...model.Notification) {
+	if err := repository.CreateNotifications(list); err != nil {
+		log.Println("warn: failed to store notifications:", err)
+	}
+}
+
+func (s *NotificationService) List(userID uint, unreadOnly bool, limit int) ([]model.Notification, error) {
+	return repository.ListNotifications(userID, unreadOnly, limit)
+}
+
+// MarkRead returns false if the notification does not exist or belongs to another user.
+func (s *NotificationService) MarkRead(userID, id uint) (bool, error) {
+	return repository.MarkNotificationRead(userID, id, time.Now())
+}
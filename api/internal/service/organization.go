@@ -1,24 +1,199 @@
 package service
 
 import (
+	"errors"
+	"log"
+	"sort"
+
 	"2gis-calm-map/api/internal/model"
 	"2gis-calm-map/api/internal/repository"
+
+	"gorm.io/gorm"
 )
 
 type OrganizationService struct{}
 
 func NewOrganizationService() *OrganizationService { return &OrganizationService{} }
 
-func (s *OrganizationService) Create(org *model.Organization) error {
-	return repository.CreateOrganization(org)
+func (s *OrganizationService) Create(org *model.Organization, actorID uint) error {
+	org.AddressNormalized = model.NormalizeAddress(org.Address)
+	if org.Latitude == nil || org.Longitude == nil {
+		if lat, lon, ok := geocodeAddress(org.Address); ok {
+			org.Latitude, org.Longitude = &lat, &lon
+		}
+	}
+	return repository.CreateOrganizationWithRevision(org, actorID)
 }
 
+// geocodeAddress asks the configured geocoder; failures are logged and treated as "unknown address".
+func geocodeAddress(address string) (float64, float64, bool) {
+	if geocoder == nil || address == "" {
+		return 0, 0, false
+	}
+	lat, lon, ok, err := geocoder.Geocode(address)
+	if err != nil {
+		log.Println("warn: geocoding failed:", err)
+		return 0, 0, false
+	}
+	return lat, lon, ok
+}
+
+// ErrOwnerHasManyOrganizations: the owner has several organizations (e.g. brand branches), so "the owner's
+// organization" is ambiguous and a specific one has to be addressed by id.
+var ErrOwnerHasManyOrganizations = errors.New("owner has several organizations: list them via GET /me/organizations and use /organization/:organization_id")
+
+// GetByOwner returns the owner's only organization: gorm.ErrRecordNotFound if there is none,
+// ErrOwnerHasManyOrganizations if there are several.
 func (s *OrganizationService) GetByOwner(ownerID uint) (model.Organization, error) {
-	return repository.GetOrganizationByOwner(ownerID)
+	orgs, err := repository.ListOrganizationsByOwner(ownerID, 2)
+	switch {
+	case err != nil:
+		return model.Organization{}, err
+	case len(orgs) == 0:
+		return model.Organization{}, gorm.ErrRecordNotFound
+	case len(orgs) > 1:
+		return model.Organization{}, ErrOwnerHasManyOrganizations
+	}
+	return orgs[0], nil
+}
+
+// ListByOwner returns all organizations of the owner (brand branches included), oldest first.
+func (s *OrganizationService) ListByOwner(ownerID uint) ([]model.Organization, error) {
+	return repository.ListOrganizationsByOwner(ownerID, 0)
 }
 
 func (s *OrganizationService) UpdateByOwner(ownerID uint, updates map[string]interface{}) (model.Organization, error) {
-	return repository.UpdateOrganizationByOwner(ownerID, updates)
+	before, err := s.GetByOwner(ownerID)
+	if err != nil {
+		return before, err
+	}
+	return s.update(before, ownerID, updates)
+}
+
+// UpdateByID updates a specific organization (owner of the organization or its brand, admin).
+func (s *OrganizationService) UpdateByID(id, actorID uint, updates map[string]interface{}) (model.Organization, error) {
+	before, err := repository.GetOrganizationByID(id)
+	if err != nil {
+		return before, err
+	}
+	return s.update(before, actorID, updates)
+}
+
+// update normalizes the address, fills missing coordinates, saves the change together with its revision
+// and refreshes rankings affected by the change.
+func (s *OrganizationService) update(before model.Organization, actorID uint, updates map[string]interface{}) (model.Organization, error) {
+	if addr, ok := updates["address"].(string); ok {
+		updates["address_normalized"] = model.NormalizeAddress(addr)
+	}
+	fillCoordinates(before, updates)
+	org, err := repository.UpdateOrganizationWithRevision(before.ID, updates, &model.OrganizationRevision{
+		ActorID: actorID,
+		Action:  model.RevisionActionUpdate,
+		Before:  model.SnapshotOf(before),
+	})
+	if err != nil {
+		return before, err
+	}
+	// смена типа или статуса меняет состав групп для перцентилей и лидербордов — пересчитываем затронутые типы
+	if org.OrganizationType != before.OrganizationType {
+		refreshRankings(before.OrganizationType, org.OrganizationType)
+	} else if org.Status != before.Status {
+		refreshRankings(org.OrganizationType)
+	}
+	return org, nil
+}
+
+// fillCoordinates adds geocoded coordinates to updates if the organization would be left without them.
+func fillCoordinates(before model.Organization, updates map[string]interface{}) {
+	_, hasLat := updates["latitude"]
+	_, hasLon := updates["longitude"]
+	if (before.Latitude != nil || hasLat) && (before.Longitude != nil || hasLon) {
+		return
+	}
+	address := before.Address
+	if addr, ok := updates["address"].(string); ok {
+		address = addr
+	}
+	if lat, lon, ok := geocodeAddress(address); ok {
+		updates["latitude"], updates["longitude"] = lat, lon
+	}
+}
+
+// refreshRankings schedules percentile and leaderboard rebuilds of the given types.
+func refreshRankings(types ...string) {
+	for _, t := range types {
+		percentileService.MarkDirty(t, model.ParamNames)
+		leaderboardService.MarkDirty(t)
+	}
+}
+
+// SetStatus changes organization status (see model.OrganizationStatus*); archived ones leave rankings.
+func (s *OrganizationService) SetStatus(id uint, status string, actorID uint) (model.Organization, error) {
+	before, err := repository.GetOrganizationByID(id)
+	if err != nil {
+		return before, err
+	}
+	if before.Status == status {
+		return before, nil
+	}
+	org, err := repository.UpdateOrganizationWithRevision(id, map[string]interface{}{"status": status}, &model.OrganizationRevision{
+		ActorID: actorID,
+		Action:  model.RevisionActionStatus,
+		Before:  model.SnapshotOf(before),
+	})
+	if err != nil {
+		return before, err
+	}
+	refreshRankings(org.OrganizationType)
+	return org, nil
+}
+
+// UpdateMedia stores new map/picture paths.
+func (s *OrganizationService) UpdateMedia(id uint, actorID uint, updates map[string]interface{}) error {
+	org, err := repository.GetOrganizationByID(id)
+	if err != nil {
+		return err
+	}
+	_, err = repository.UpdateOrganizationWithRevision(id, updates, &model.OrganizationRevision{
+		ActorID: actorID,
+		Action:  model.RevisionActionMedia,
+		Before:  model.SnapshotOf(org),
+	})
+	return err
+}
+
+// Delete soft-deletes an organization: it disappears from all public endpoints, reviews stay for admins.
+func (s *OrganizationService) Delete(id uint, actorID uint) error {
+	org, err := repository.GetOrganizationByID(id)
+	if err != nil {
+		return err
+	}
+	snapshot := model.SnapshotOf(org)
+	rev := model.OrganizationRevision{ActorID: actorID, Action: model.RevisionActionDelete, Before: snapshot, After: snapshot}
+	if err := repository.SoftDeleteOrganization(id, &rev); err != nil {
+		return err
+	}
+	refreshRankings(org.OrganizationType)
+	return nil
+}
+
+// Restore undoes Delete.
+func (s *OrganizationService) Restore(id uint, actorID uint) (model.Organization, error) {
+	org, err := repository.GetOrganizationByIDUnscoped(id)
+	if err != nil {
+		return org, err
+	}
+	if !org.DeletedAt.Valid {
+		return org, nil
+	}
+	snapshot := model.SnapshotOf(org)
+	rev := model.OrganizationRevision{ActorID: actorID, Action: model.RevisionActionRestore, Before: snapshot, After: snapshot}
+	if err := repository.RestoreOrganization(id, &rev); err != nil {
+		return org, err
+	}
+	org.DeletedAt = gorm.DeletedAt{}
+	refreshRankings(org.OrganizationType)
+	return org, nil
 }
 
 func (s *OrganizationService) GetByType(orgType string) ([]model.Organization, error) {
@@ -29,6 +204,113 @@ func (s *OrganizationService) GetByID(id uint) (model.Organization, error) {
 	return repository.GetOrganizationByID(id)
 }
 
+// GetByIDUnscoped also returns soft-deleted organizations (admin access to archived reviews).
+func (s *OrganizationService) GetByIDUnscoped(id uint) (model.Organization, error) {
+	return repository.GetOrganizationByIDUnscoped(id)
+}
+
 func (s *OrganizationService) GetByAddress(address string) (model.Organization, error) {
 	return repository.GetOrganizationByAddress(address)
 }
+
+// Address lookup: exact string, then normalized form, then trigram candidates.
+const (
+	AddressMatchExact      = "exact"
+	AddressMatchNormalized = "normalized"
+	AddressMatchSimilar    = "similar"
+
+	addressMinSimilarity = 0.3
+	addressMaxCandidates = 5
+)
+
+// AddressMatch is a lookup result; Similarity is 1 for exact and normalized matches.
+type AddressMatch struct {
+	Organization model.Organization
+	Match        string
+	Similarity   float64
+	HouseMatch   bool // номер дома совпадает с запрошенным
+}
+
+// FindByAddress returns a single exact/normalized match, or up to addressMaxCandidates similar addresses
+// (candidates with the requested house number first). Empty result means nothing is close enough.
+func (s *OrganizationService) FindByAddress(address string) ([]AddressMatch, error) {
+	org, err := repository.GetOrganizationByAddress(address)
+	if err == nil {
+		return []AddressMatch{{Organization: org, Match: AddressMatchExact, Similarity: 1, HouseMatch: true}}, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	normalized, house := model.ParseAddress(address)
+	if normalized == "" {
+		return []AddressMatch{}, nil
+	}
+	org, err = repository.GetOrganizationByNormalizedAddress(normalized)
+	if err == nil {
+		return []AddressMatch{{Organization: org, Match: AddressMatchNormalized, Similarity: 1, HouseMatch: true}}, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// берём с запасом: кандидаты с другим номером дома уйдут в конец
+	candidates, err := repository.FindOrganizationsBySimilarAddress(normalized, addressMinSimilarity, addressMaxCandidates*4)
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]AddressMatch, 0, len(candidates))
+	for _, c := range candidates {
+		_, candidateHouse := model.ParseAddress(c.Address)
+		matches = append(matches, AddressMatch{
+			Organization: c.Organization,
+			Match:        AddressMatchSimilar,
+			Similarity:   c.Similarity,
+			HouseMatch:   house != "" && house == candidateHouse,
+		})
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].HouseMatch && !matches[j].HouseMatch })
+	if len(matches) > addressMaxCandidates {
+		matches = matches[:addressMaxCandidates]
+	}
+	return matches, nil
+}
+
+// BackfillNormalizedAddresses fills address_normalized for organizations created before it existed.
+func (s *OrganizationService) BackfillNormalizedAddresses() (int, error) {
+	orgs, err := repository.ListOrganizationsWithoutNormalizedAddress()
+	if err != nil {
+		return 0, err
+	}
+	for _, o := range orgs {
+		if err := repository.UpdateOrganizationFields(o.ID, map[string]interface{}{"address_normalized": model.NormalizeAddress(o.Address)}); err != nil {
+			return 0, err
+		}
+	}
+	return len(orgs), nil
+}
+
+// BackfillCoordinates geocodes organizations without coordinates; returns how many were filled and checked.
+func (s *OrganizationService) BackfillCoordinates() (filled, total int, err error) {
+	if geocoder == nil {
+		return 0, 0, errors.New("geocoder is not configured")
+	}
+	orgs, err := repository.ListOrganizationsWithoutCoordinates()
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, o := range orgs {
+		lat, lon, ok := geocodeAddress(o.Address)
+		if !ok {
+			continue
+		}
+		if err := repository.UpdateOrganizationFields(o.ID, map[string]interface{}{"latitude": lat, "longitude": lon}); err != nil {
+			return filled, len(orgs), err
+		}
+		filled++
+	}
+	return filled, len(orgs), nil
+}
+
+func (s *OrganizationService) GetByIDs(ids []uint) ([]model.Organization, error) {
+	return repository.GetOrganizationsByIDs(ids)
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrClaimNotPending         = repository.ErrClaimNotPending
+	ErrClaimAlreadyOwner       = errors.New("you already own this organization")
+	ErrClaimDuplicate          = errors.New("you already have a pending claim for this organization")
+	ErrClaimantHasOrganization = errors.New("claimant already owns an organization")
+)
+
+var notificationService = NewNotificationService()
+
+type OrganizationClaimService struct{}
+
+func NewOrganizationClaimService() *OrganizationClaimService { return &OrganizationClaimService{} }
+
+// ownsOtherOrganization applies the "one organization per owner" rule (admins are not limited); tx may be nil.
+func ownsOtherOrganization(tx *gorm.DB, user model.User) error {
+	if user.Role == "admin" {
+		return nil
+	}
+	n, err := repository.CountOrganizationsByOwner(tx, user.ID)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return ErrClaimantHasOrganization
+	}
+	return nil
+}
+
+// Submit creates a pending claim and notifies admins.
+func (s *OrganizationClaimService) Submit(orgID uint, claimant model.User, evidence, contact string) (model.OrganizationClaim, error) {
+	org, err := repository.GetOrganizationByID(orgID)
+	if err != nil {
+		return model.OrganizationClaim{}, err
+	}
+	if org.OwnerID == claimant.ID {
+		return model.OrganizationClaim{}, ErrClaimAlreadyOwner
+	}
+	if err := ownsOtherOrganization(nil, claimant); err != nil {
+		return model.OrganizationClaim{}, err
+	}
+	dup, err := repository.HasPendingOrganizationClaim(orgID, claimant.ID)
+	if err != nil {
+		return model.OrganizationClaim{}, err
+	}
+	if dup {
+		return model.OrganizationClaim{}, ErrClaimDuplicate
+	}
+
+	claim := model.OrganizationClaim{OrganizationID: orgID, ClaimantID: claimant.ID, Evidence: evidence, Contact: contact, Status: model.ClaimStatusPending}
+	if err := repository.CreateOrganizationClaim(&claim); err != nil {
+		return claim, err
+	}
+	// заявка уже сохранена: сбой уведомления только логируем, иначе повтор запроса упрётся в ErrClaimDuplicate
+	admins, err := repository.ListUserIDsByRole("admin")
+	if err != nil {
+		log.Println("warn: failed to list admins for claim notification:", err)
+		return claim, nil
+	}
+	list := make([]model.Notification, 0, len(admins))
+	for _, id := range admins {
+		list = append(list, model.Notification{
+			UserID: id, Kind: model.NotificationClaimSubmitted, OrganizationID: &orgID, ClaimID: &claim.ID,
+			Message: fmt.Sprintf("Новая заявка на управление организацией «%s»", organizationTitle(org)),
+		})
+	}
+	notificationService.Notify(list...)
+	return claim, nil
+}
+
+// organizationTitle is the name, or the address for organizations without a name.
+func organizationTitle(org model.Organization) string {
+	if org.Name != "" {
+		return org.Name
+	}
+	return org.Address
+}
+
+func (s *OrganizationClaimService) Get(id uint) (model.OrganizationClaim, error) {
+	return repository.GetOrganizationClaim(id)
+}
+
+func (s *OrganizationClaimService) ListByClaimant(userID uint) ([]model.OrganizationClaim, error) {
+	return repository.ListOrganizationClaims(userID, "")
+}
+
+func (s *OrganizationClaimService) List(status string) ([]model.OrganizationClaim, error) {
+	return repository.ListOrganizationClaims(0, status)
+}
+
+// Review approves (transferring OwnerID) or rejects a claim and notifies everyone involved.
+func (s *OrganizationClaimService) Review(id, reviewerID uint, approve bool, note string) (model.OrganizationClaim, error) {
+	var check func(tx *gorm.DB, claim model.OrganizationClaim) error
+	if approve {
+		check = func(tx *gorm.DB, claim model.OrganizationClaim) error {
+			claimant, err := repository.GetUserByID(claim.ClaimantID)
+			if err != nil {
+				return err
+			}
+			return ownsOtherOrganization(tx, claimant)
+		}
+	}
+	res, err := repository.ReviewOrganizationClaim(id, reviewerID, approve, note, check)
+	if err != nil {
+		return res.Claim, err
+	}
+
+	org, err := repository.GetOrganizationByID(res.Claim.OrganizationID)
+	if err != nil {
+		return res.Claim, err
+	}
+	title := organizationTitle(org)
+	orgID, claimID := org.ID, res.Claim.ID
+	var list []model.Notification
+	if approve {
+		list = append(list, model.Notification{
+			UserID: res.Claim.ClaimantID, Kind: model.NotificationClaimApproved, OrganizationID: &orgID, ClaimID: &claimID,
+			Message: fmt.Sprintf("Заявка одобрена: теперь вы управляете организацией «%s»", title),
+		})
+		if res.TransferredFrom != 0 && res.TransferredFrom != res.Claim.ClaimantID {
+			list = append(list, model.Notification{
+				UserID: res.TransferredFrom, Kind: model.NotificationOrganizationTransferred, OrganizationID: &orgID, ClaimID: &claimID,
+				Message: fmt.Sprintf("Управление организацией «%s» передано другому владельцу", title),
+			})
+		}
+		for _, other := range res.AutoRejected {
+			otherID := other.ID
+			list = append(list, model.Notification{
+				UserID: other.ClaimantID, Kind: model.NotificationClaimRejected, OrganizationID: &orgID, ClaimID: &otherID,
+				Message: fmt.Sprintf("Заявка на организацию «%s» отклонена: одобрена другая заявка", title),
+			})
+		}
+	} else {
+		msg := fmt.Sprintf("Заявка на организацию «%s» отклонена", title)
+		if note != "" {
+			msg += ": " + note
+		}
+		list = append(list, model.Notification{UserID: res.Claim.ClaimantID, Kind: model.NotificationClaimRejected, OrganizationID: &orgID, ClaimID: &claimID, Message: msg})
+	}
+	notificationService.Notify(list...)
+	return res.Claim, nil
+}
@@ -1,10 +1,29 @@
 package service
 
 import (
+	"log"
+
 	"2gis-calm-map/api/internal/model"
 	"2gis-calm-map/api/internal/repository"
 )
 
+var (
+	similarityService  = NewOrganizationSimilarityService()
+	percentileService  = NewOrganizationPercentileService()
+	leaderboardService = NewLeaderboardService()
+)
+
+// sensitiveWeight is the weight of a rating whose author marked the param as important in UserParams.
+// Флаги берутся на момент отзыва; последующая смена UserParams не пересчитывает агрегаты.
+const sensitiveWeight = 2.0
+
+func sensitivityWeight(flagged bool) float64 {
+	if flagged {
+		return sensitiveWeight
+	}
+	return 1
+}
+
 type OrganizationCommentService struct{}
 
 func NewOrganizationCommentService() *OrganizationCommentService {
@@ -16,29 +35,53 @@ func (s *OrganizationCommentService) CreateWithAggregation(c *model.Organization
 	if err := repository.CreateOrganizationComment(c); err != nil {
 		return err
 	}
+	// флаги чувствительности автора (нет записи — все false)
+	var flags model.UserParams
+	if up, err := repository.GetUserParamsByUserID(c.UserID); err == nil {
+		flags = up
+	}
+
 	// update aggregates for each non-nil numeric field
-	apply := func(val *uint, sum *uint, count *uint, avg *float64) {
+	apply := func(val *uint, sum *uint, count *uint, avg *float64, flagged bool, weightSum *float64, weightedSum *float64, sensitiveAvg *float64) {
 		if val == nil || *val == 0 { // treat 0 as not provided per spec ("ненулевых")
 			return
 		}
 		*sum += *val
 		*count += 1
 		*avg = float64(*sum) / float64(*count)
+
+		w := sensitivityWeight(flagged)
+		*weightSum += w
+		*weightedSum += w * float64(*val)
+		*sensitiveAvg = *weightedSum / *weightSum
 	}
-	apply(c.AppearanceValue, &p.AppearanceSum, &p.AppearanceCount, &p.AppearanceAvg)
-	apply(c.LightingValue, &p.LightingSum, &p.LightingCount, &p.LightingAvg)
-	apply(c.SmellValue, &p.SmellSum, &p.SmellCount, &p.SmellAvg)
-	apply(c.TemperatureValue, &p.TemperatureSum, &p.TemperatureCount, &p.TemperatureAvg)
-	apply(c.TactilityValue, &p.TactilitySum, &p.TactilityCount, &p.TactilityAvg)
-	apply(c.SignageValue, &p.SignageSum, &p.SignageCount, &p.SignageAvg)
-	apply(c.IntuitivenessValue, &p.IntuitivenessSum, &p.IntuitivenessCount, &p.IntuitivenessAvg)
-	apply(c.StaffAttitudeValue, &p.StaffAttitudeSum, &p.StaffAttitudeCount, &p.StaffAttitudeAvg)
-	apply(c.PeopleDensityValue, &p.PeopleDensitySum, &p.PeopleDensityCount, &p.PeopleDensityAvg)
-	apply(c.SelfServiceValue, &p.SelfServiceSum, &p.SelfServiceCount, &p.SelfServiceAvg)
-	apply(c.CalmnessValue, &p.CalmnessSum, &p.CalmnessCount, &p.CalmnessAvg)
+	apply(c.AppearanceValue, &p.AppearanceSum, &p.AppearanceCount, &p.AppearanceAvg, flags.Appearance, &p.AppearanceWeightSum, &p.AppearanceWeightedSum, &p.AppearanceSensitiveAvg)
+	apply(c.LightingValue, &p.LightingSum, &p.LightingCount, &p.LightingAvg, flags.Lighting, &p.LightingWeightSum, &p.LightingWeightedSum, &p.LightingSensitiveAvg)
+	apply(c.SmellValue, &p.SmellSum, &p.SmellCount, &p.SmellAvg, flags.Smell, &p.SmellWeightSum, &p.SmellWeightedSum, &p.SmellSensitiveAvg)
+	apply(c.TemperatureValue, &p.TemperatureSum, &p.TemperatureCount, &p.TemperatureAvg, flags.Temperature, &p.TemperatureWeightSum, &p.TemperatureWeightedSum, &p.TemperatureSensitiveAvg)
+	apply(c.TactilityValue, &p.TactilitySum, &p.TactilityCount, &p.TactilityAvg, flags.Tactility, &p.TactilityWeightSum, &p.TactilityWeightedSum, &p.TactilitySensitiveAvg)
+	apply(c.SignageValue, &p.SignageSum, &p.SignageCount, &p.SignageAvg, flags.Signage, &p.SignageWeightSum, &p.SignageWeightedSum, &p.SignageSensitiveAvg)
+	apply(c.IntuitivenessValue, &p.IntuitivenessSum, &p.IntuitivenessCount, &p.IntuitivenessAvg, flags.Intuitiveness, &p.IntuitivenessWeightSum, &p.IntuitivenessWeightedSum, &p.IntuitivenessSensitiveAvg)
+	apply(c.StaffAttitudeValue, &p.StaffAttitudeSum, &p.StaffAttitudeCount, &p.StaffAttitudeAvg, flags.StaffAttitude, &p.StaffAttitudeWeightSum, &p.StaffAttitudeWeightedSum, &p.StaffAttitudeSensitiveAvg)
+	apply(c.PeopleDensityValue, &p.PeopleDensitySum, &p.PeopleDensityCount, &p.PeopleDensityAvg, flags.PeopleDensity, &p.PeopleDensityWeightSum, &p.PeopleDensityWeightedSum, &p.PeopleDensitySensitiveAvg)
+	apply(c.SelfServiceValue, &p.SelfServiceSum, &p.SelfServiceCount, &p.SelfServiceAvg, flags.SelfService, &p.SelfServiceWeightSum, &p.SelfServiceWeightedSum, &p.SelfServiceSensitiveAvg)
+	apply(c.CalmnessValue, &p.CalmnessSum, &p.CalmnessCount, &p.CalmnessAvg, flags.Calmness, &p.CalmnessWeightSum, &p.CalmnessWeightedSum, &p.CalmnessSensitiveAvg)
 
 	// persist updated aggregates
-	return repository.UpdateOrganizationParams(p)
+	if err := repository.UpdateOrganizationParams(p); err != nil {
+		return err
+	}
+	// derived data: similarity vector now, percentiles and leaderboards by their workers (комментарий уже сохранён — не валим запрос)
+	if err := similarityService.Refresh(*p); err != nil {
+		log.Println("warn: failed to refresh organization vector:", err)
+	}
+	if org, err := repository.GetOrganizationByID(c.OrganizationID); err != nil {
+		log.Println("warn: failed to load organization for percentiles:", err)
+	} else {
+		percentileService.MarkDirty(org.OrganizationType, c.RatedParams())
+		leaderboardService.MarkDirty(org.OrganizationType)
+	}
+	return nil
 }
 
 func (s *OrganizationCommentService) ListByOrganization(orgID uint) ([]model.OrganizationComment, error) {
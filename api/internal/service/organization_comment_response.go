@@ -0,0 +1,47 @@
+package service
+
+import (
+	"fmt"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/repository"
+)
+
+type OrganizationCommentResponseService struct{}
+
+func NewOrganizationCommentResponseService() *OrganizationCommentResponseService {
+	return &OrganizationCommentResponseService{}
+}
+
+// Respond creates or edits the organization's reply to a review of this organization.
+// The review author is notified when the reply is first posted (edits are silent).
+func (s *OrganizationCommentResponseService) Respond(org model.Organization, commentID, authorID uint, text string) (model.OrganizationCommentResponse, bool, error) {
+	comment, err := repository.GetOrganizationComment(org.ID, commentID)
+	if err != nil {
+		return model.OrganizationCommentResponse{}, false, err
+	}
+	r := model.OrganizationCommentResponse{CommentID: comment.ID, OrganizationID: org.ID, AuthorID: authorID, Text: text}
+	created, err := repository.UpsertCommentResponse(&r)
+	if err != nil || !created {
+		return r, false, err
+	}
+	if comment.UserID != authorID {
+		orgID, cID := org.ID, comment.ID
+		notificationService.Notify(model.Notification{
+			UserID:         comment.UserID,
+			Kind:           model.NotificationCommentResponse,
+			Message:        fmt.Sprintf("Организация «%s» ответила на ваш отзыв", organizationTitle(org)),
+			OrganizationID: &orgID,
+			CommentID:      &cID,
+		})
+	}
+	return r, true, nil
+}
+
+// Delete removes the reply; false means the review has no reply (or is not a review of this organization).
+func (s *OrganizationCommentResponseService) Delete(orgID, commentID uint) (bool, error) {
+	if _, err := repository.GetOrganizationComment(orgID, commentID); err != nil {
+		return false, err
+	}
+	return repository.DeleteCommentResponse(commentID)
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/repository"
+)
+
+// Dashboard periods (review volume buckets).
+const (
+	DashboardPeriodDay   = "day"
+	DashboardPeriodWeek  = "week"
+	DashboardPeriodMonth = "month"
+)
+
+const dashboardWeakest = 3
+
+// DashboardOptions configures Dashboard; zero values fall back to defaults.
+type DashboardOptions struct {
+	Period  string // day | week (default) | month
+	Periods int    // число последних периодов в динамике, по умолчанию 12
+	Words   int    // размер топа слов, по умолчанию 20
+	Recent  int    // число последних отзывов, по умолчанию 10
+}
+
+// VolumePoint is the number of reviews (and their mean avg_val) in one period; periods without reviews are included.
+type VolumePoint struct {
+	PeriodStart time.Time `json:"period_start"`
+	Reviews     int       `json:"reviews"`
+	Average     *float64  `json:"average"`
+}
+
+// FactorComparison compares a factor of the organization with all active organizations of its type.
+type FactorComparison struct {
+	Param       string  `json:"param"`
+	Average     float64 `json:"average"`
+	Count       uint    `json:"count"`
+	TypeAverage float64 `json:"type_average"`
+	TypeCount   int64   `json:"type_count"`
+	// Delta = average - type_average; 0 если у организации или типа нет оценок
+	Delta float64 `json:"delta"`
+}
+
+type WordCount struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+// OrganizationDashboard is the owner analytics summary.
+type OrganizationDashboard struct {
+	TotalReviews   int                         `json:"total_reviews"`
+	UndatedReviews int                         `json:"undated_reviews"` // отзывы без даты (оставлены до её появления): входят в total_reviews, но не в volume
+	Period         string                      `json:"period"`
+	Volume         []VolumePoint               `json:"volume"`
+	Factors        []FactorComparison          `json:"factors"`
+	Weakest        []FactorComparison          `json:"weakest"`
+	Words          []WordCount                 `json:"words"`
+	FactorWords    map[string][]WordCount      `json:"factor_words"`
+	Recent         []model.OrganizationComment `json:"-"` // последние отзывы; хендлер отдаёт их в формате списка комментариев
+}
+
+// dashboardStopWords are skipped when counting words in per-factor comments.
+var dashboardStopWords = map[string]bool{}
+
+func init() {
+	for _, w := range strings.Fields(`и в во не что он на я с со как а то все она так его но да ты к у же вы за бы по только
+		ее её мне было вот от меня еще ещё нет о из ему теперь когда даже ну вдруг ли если уже или ни быть был него до вас
+		нибудь опять уж вам ведь там потом себя ничего ей может они тут где есть надо ней для мы тебя их чем была сам чтоб
+		без будто чего раз тоже себе под будет ж тогда кто этот того потому этого какой совсем ним здесь этом один почти мой
+		тем чтобы нее неё сейчас были куда зачем всех никогда можно при наконец два об другой хоть после над больше тот через
+		эти нас про всего них какая много разве три эту моя впрочем хорошо свою этой перед иногда лучше чуть том нельзя такой
+		им более всегда конечно всю между очень это the and for with was are not but you this that have very`) {
+		dashboardStopWords[w] = true
+	}
+}
+
+// Dashboard builds review analytics of an organization (org must have Params loaded, may be nil).
+func (s *OrganizationService) Dashboard(org model.Organization, opts DashboardOptions, now time.Time) (OrganizationDashboard, error) {
+	if opts.Period == "" {
+		opts.Period = DashboardPeriodWeek
+	}
+	if opts.Periods <= 0 {
+		opts.Periods = 12
+	}
+	if opts.Words <= 0 {
+		opts.Words = 20
+	}
+	if opts.Recent <= 0 {
+		opts.Recent = 10
+	}
+
+	total, undated, err := repository.CountOrganizationComments(org.ID)
+	if err != nil {
+		return OrganizationDashboard{}, err
+	}
+	typeFactors, err := repository.TypeFactors(org.OrganizationType)
+	if err != nil {
+		return OrganizationDashboard{}, err
+	}
+	texts, err := repository.ListOrganizationCommentTexts(org.ID)
+	if err != nil {
+		return OrganizationDashboard{}, err
+	}
+
+	d := OrganizationDashboard{TotalReviews: int(total), UndatedReviews: int(undated), Period: opts.Period}
+	if d.Volume, err = reviewVolume(org.ID, opts.Period, opts.Periods, now); err != nil {
+		return OrganizationDashboard{}, err
+	}
+	d.Factors = compareFactors(org.Params, typeFactors)
+	d.Weakest = weakestFactors(d.Factors, dashboardWeakest)
+	d.Words, d.FactorWords = frequentWords(texts, opts.Words)
+	if d.Recent, err = repository.ListRecentOrganizationComments(org.ID, opts.Recent); err != nil {
+		return OrganizationDashboard{}, err
+	}
+	return d, nil
+}
+
+// periodStart truncates t to the start of its day / ISO week / month in loc.
+func periodStart(t time.Time, period string, loc *time.Location) time.Time {
+	t = t.In(loc)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	switch period {
+	case DashboardPeriodDay:
+		return day
+	case DashboardPeriodMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+	}
+	return day.AddDate(0, 0, 1-isoWeekday(day))
+}
+
+// reviewVolume returns review counts in the last n periods (oldest first), empty periods included; undated reviews are not counted.
+func reviewVolume(orgID uint, period string, n int, now time.Time) ([]VolumePoint, error) {
+	loc, err := time.LoadLocation(model.DefaultTimezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	starts := make([]time.Time, n)
+	starts[n-1] = periodStart(now, period, loc)
+	for i := n - 2; i >= 0; i-- {
+		// шаг назад: начало периода, в который попадает момент перед starts[i+1]
+		starts[i] = periodStart(starts[i+1].Add(-time.Nanosecond), period, loc)
+	}
+	buckets, err := repository.ReviewVolume(orgID, period, loc.String(), starts[0])
+	if err != nil {
+		return nil, err
+	}
+	byStart := make(map[time.Time]repository.ReviewVolumeBucket, len(buckets))
+	for _, b := range buckets {
+		// начало периода приходит как местное время без пояса
+		st := time.Date(b.PeriodStart.Year(), b.PeriodStart.Month(), b.PeriodStart.Day(), 0, 0, 0, 0, loc)
+		byStart[st] = b
+	}
+	points := make([]VolumePoint, n)
+	for i, st := range starts {
+		b := byStart[st]
+		points[i] = VolumePoint{PeriodStart: st, Reviews: b.Reviews, Average: b.Average}
+	}
+	return points, nil
+}
+
+func compareFactors(p *model.OrganizationParams, typeFactors []repository.FactorAggregate) []FactorComparison {
+	byParam := make(map[string]repository.FactorAggregate, len(typeFactors))
+	for _, f := range typeFactors {
+		byParam[f.Param] = f
+	}
+	out := make([]FactorComparison, 0, len(model.ParamNames))
+	for _, name := range model.ParamNames {
+		fc := FactorComparison{Param: name, TypeAverage: byParam[name].Average, TypeCount: byParam[name].Count}
+		if p != nil {
+			fc.Average, fc.Count, _ = p.Factor(name)
+		}
+		if fc.Count > 0 && fc.TypeCount > 0 {
+			fc.Delta = fc.Average - fc.TypeAverage
+		}
+		out = append(out, fc)
+	}
+	return out
+}
+
+// weakestFactors returns up to n rated factors with the lowest average (ties — further below the type average first).
+func weakestFactors(factors []FactorComparison, n int) []FactorComparison {
+	rated := make([]FactorComparison, 0, len(factors))
+	for _, f := range factors {
+		if f.Count > 0 {
+			rated = append(rated, f)
+		}
+	}
+	sort.SliceStable(rated, func(i, j int) bool {
+		if rated[i].Average != rated[j].Average {
+			return rated[i].Average < rated[j].Average
+		}
+		return rated[i].Delta < rated[j].Delta
+	})
+	if len(rated) > n {
+		rated = rated[:n]
+	}
+	return rated
+}
+
+// frequentWords counts words of per-factor comments (only <name>_comment fields are used): top n overall and top 5 per factor.
+func frequentWords(comments []model.OrganizationComment, n int) ([]WordCount, map[string][]WordCount) {
+	total := map[string]int{}
+	perFactor := map[string]map[string]int{}
+	for _, c := range comments {
+		for _, name := range model.ParamNames {
+			_, text, _ := c.Param(name)
+			if text == nil {
+				continue
+			}
+			for _, w := range commentWords(*text) {
+				total[w]++
+				if perFactor[name] == nil {
+					perFactor[name] = map[string]int{}
+				}
+				perFactor[name][w]++
+			}
+		}
+	}
+	byFactor := make(map[string][]WordCount, len(perFactor))
+	for name, counts := range perFactor {
+		byFactor[name] = topWords(counts, 5)
+	}
+	return topWords(total, n), byFactor
+}
+
+// commentWords lowercases text and returns words of 3+ letters that are not stop words.
+func commentWords(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) && r != '-' })
+	out := fields[:0]
+	for _, f := range fields {
+		f = strings.Trim(strings.ReplaceAll(f, "ё", "е"), "-")
+		if utf8.RuneCountInString(f) < 3 || dashboardStopWords[f] {
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+func topWords(counts map[string]int, n int) []WordCount {
+	list := make([]WordCount, 0, len(counts))
+	for w, c := range counts {
+		list = append(list, WordCount{Word: w, Count: c})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		return list[i].Word < list[j].Word
+	})
+	if len(list) > n {
+		list = list[:n]
+	}
+	return list
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/repository"
+)
+
+// Export formats.
+const (
+	ExportFormatGeoJSON = "geojson"
+	ExportFormatKML     = "kml"
+)
+
+// exportBatchSize rows are read from the database at a time while streaming.
+const exportBatchSize = 500
+
+var ErrUnknownExportFormat = errors.New("unknown export format (expected geojson or kml)")
+
+// exportProperty is a named feature attribute; nil values are written as null (GeoJSON) or omitted (KML).
+type exportProperty struct {
+	name  string
+	value interface{}
+}
+
+// exportProperties: organization fields and, per factor, "<param>" (average of the requested aggregate,
+// nil if unrated) and "<param>_count".
+func exportProperties(org model.Organization, aggregate string) []exportProperty {
+	props := []exportProperty{
+		{"id", org.ID},
+		{"name", org.Name},
+		{"address", org.Address},
+		{"organization_type", org.OrganizationType},
+		{"status", org.Status},
+		{"brand_id", org.BrandID},
+		{"phone", org.Phone},
+		{"website", org.Website},
+	}
+	var params model.OrganizationParams
+	if org.Params != nil {
+		params = org.Params.WithAggregate(aggregate)
+	}
+	for _, name := range model.ParamNames {
+		avg, count, _ := params.Factor(name)
+		var value interface{}
+		if count > 0 {
+			value = avg
+		}
+		props = append(props, exportProperty{name, value}, exportProperty{name + "_count", count})
+	}
+	return props
+}
+
+// organizationEncoder writes a feature collection: begin, a feature per organization, end.
+type organizationEncoder interface {
+	begin() error
+	feature(org model.Organization) error
+	end() error
+}
+
+type geoJSONEncoder struct {
+	w         io.Writer
+	aggregate string
+	n         int
+}
+
+func (e *geoJSONEncoder) begin() error {
+	_, err := io.WriteString(e.w, `{"type":"FeatureCollection","features":[`)
+	return err
+}
+
+func (e *geoJSONEncoder) feature(org model.Organization) error {
+	props := map[string]interface{}{}
+	for _, p := range exportProperties(org, e.aggregate) {
+		props[p.name] = p.value
+	}
+	b, err := json.Marshal(map[string]interface{}{
+		"type":       "Feature",
+		"id":         org.ID,
+		"geometry":   map[string]interface{}{"type": "Point", "coordinates": []float64{*org.Longitude, *org.Latitude}},
+		"properties": props,
+	})
+	if err != nil {
+		return err
+	}
+	if e.n > 0 {
+		b = append([]byte{','}, b...)
+	}
+	e.n++
+	_, err = e.w.Write(append(b, '\n'))
+	return err
+}
+
+func (e *geoJSONEncoder) end() error {
+	_, err := io.WriteString(e.w, "]}\n")
+	return err
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+type kmlPlacemark struct {
+	XMLName      xml.Name  `xml:"Placemark"`
+	ID           string    `xml:"id,attr"`
+	Name         string    `xml:"name"`
+	Address      string    `xml:"address,omitempty"`
+	Description  string    `xml:"description,omitempty"`
+	ExtendedData []kmlData `xml:"ExtendedData>Data"`
+	Coordinates  string    `xml:"Point>coordinates"`
+}
+
+type kmlEncoder struct {
+	w         io.Writer
+	enc       *xml.Encoder
+	aggregate string
+}
+
+func (e *kmlEncoder) begin() error {
+	_, err := io.WriteString(e.w, xml.Header+`<kml xmlns="http://www.opengis.net/kml/2.2"><Document><name>Organizations</name>`+"\n")
+	return err
+}
+
+func (e *kmlEncoder) feature(org model.Organization) error {
+	pm := kmlPlacemark{
+		ID:          fmt.Sprintf("organization-%d", org.ID),
+		Name:        org.Name,
+		Address:     org.Address,
+		Description: org.Description,
+		Coordinates: strconv.FormatFloat(*org.Longitude, 'f', -1, 64) + "," + strconv.FormatFloat(*org.Latitude, 'f', -1, 64),
+	}
+	for _, p := range exportProperties(org, e.aggregate) {
+		if v := kmlValue(p.value); v != "" {
+			pm.ExtendedData = append(pm.ExtendedData, kmlData{Name: p.name, Value: v})
+		}
+	}
+	if err := e.enc.Encode(pm); err != nil {
+		return err
+	}
+	_, err := io.WriteString(e.w, "\n")
+	return err
+}
+
+func (e *kmlEncoder) end() error {
+	_, err := io.WriteString(e.w, "</Document></kml>\n")
+	return err
+}
+
+// kmlValue formats a property for ExtendedData ("" — omit).
+func kmlValue(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case *string:
+		if x == nil {
+			return ""
+		}
+		return *x
+	case *uint:
+		if x == nil {
+			return ""
+		}
+		return strconv.FormatUint(uint64(*x), 10)
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// Export streams organizations with coordinates matching the search filters (text optional) to w as a GeoJSON
+// FeatureCollection or KML document. Nothing is written until the first batch is loaded, so an error
+// with started = false means the response can still be replaced by an error.
+func (s *OrganizationSearchService) Export(q repository.OrganizationSearchQuery, format string, w io.Writer) (started bool, err error) {
+	q, err = normalizeSearchQuery(q)
+	if err != nil {
+		return false, err
+	}
+	var enc organizationEncoder
+	switch format {
+	case ExportFormatGeoJSON:
+		enc = &geoJSONEncoder{w: w, aggregate: q.Aggregate}
+	case ExportFormatKML:
+		enc = &kmlEncoder{w: w, enc: xml.NewEncoder(w), aggregate: q.Aggregate}
+	default:
+		return false, ErrUnknownExportFormat
+	}
+	err = repository.StreamOrganizations(q, exportBatchSize, func(org model.Organization) error {
+		if !started {
+			started = true
+			if err := enc.begin(); err != nil {
+				return err
+			}
+		}
+		return enc.feature(org)
+	})
+	if err != nil {
+		return started, err
+	}
+	if !started {
+		started = true
+		if err := enc.begin(); err != nil {
+			return started, err
+		}
+	}
+	return started, enc.end()
+}
@@ -0,0 +1,537 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// Import formats.
+const (
+	ImportFormatCSV     = "csv"
+	ImportFormatGeoJSON = "geojson"
+)
+
+// Import row statuses.
+const (
+	ImportRowCreated   = "created"
+	ImportRowValid     = "valid" // прошла проверку, но не создана (dry run или атомарный импорт прерван)
+	ImportRowDuplicate = "duplicate"
+	ImportRowInvalid   = "invalid"
+	ImportRowFailed    = "failed" // прошла проверку, но вставка не удалась (построчный режим)
+)
+
+const (
+	ImportMaxRows = 5000
+	// importDuplicateRadius: организации ближе этого расстояния (м) с тем же названием считаются дублями
+	importDuplicateRadius = 25.0
+)
+
+var (
+	ErrUnknownImportFormat = errors.New("unknown import format (expected csv or geojson)")
+	ErrImportTooManyRows   = fmt.Errorf("too many rows to import (max %d)", ImportMaxRows)
+)
+
+// import columns (CSV header / GeoJSON property names, case-insensitive for CSV); address and lat/lon — as in the gazetteer.
+var (
+	importNameColumns         = []string{"name", "title"}
+	importDescriptionColumns  = []string{"description"}
+	importPhoneColumns        = []string{"phone"}
+	importWebsiteColumns      = []string{"website", "url"}
+	importTypeColumns         = []string{"organization_type", "type", "category"}
+	importBrandColumns        = []string{"brand_id"}
+	importOpeningHoursColumns = []string{"opening_hours"}
+	importQuietHoursColumns   = []string{"quiet_hours"}
+)
+
+// ImportRow is one parsed record; Problems holds parse errors, the row is validated by Import.
+type ImportRow struct {
+	Row              int // номер записи (CSV — без заголовка, GeoJSON — номер feature), с 1
+	Name             string
+	Description      string
+	Phone            *string
+	Website          *string
+	Address          string
+	Latitude         *float64
+	Longitude        *float64
+	OrganizationType string
+	BrandID          *uint
+	OpeningHours     *model.OpeningHours
+	QuietHours       *model.OpeningHours
+	Problems         []string
+}
+
+type ImportOptions struct {
+	OwnerID uint // владелец созданных организаций и автор их ревизий (admin)
+	DryRun  bool // только проверка, ничего не создаётся
+	Atomic  bool // всё или ничего: любая невалидная строка отменяет импорт, вставка одной транзакцией
+}
+
+type ImportRowResult struct {
+	Row            int      `json:"row"`
+	Status         string   `json:"status"` // created | valid | duplicate | invalid | failed
+	Name           string   `json:"name"`
+	Address        string   `json:"address"`
+	OrganizationID *uint    `json:"organization_id,omitempty"`
+	DuplicateOf    *uint    `json:"duplicate_of,omitempty"`     // существующая организация
+	DuplicateOfRow *int     `json:"duplicate_of_row,omitempty"` // более ранняя строка того же файла
+	Errors         []string `json:"errors,omitempty"`
+}
+
+type ImportResult struct {
+	DryRun     bool              `json:"dry_run"`
+	Atomic     bool              `json:"atomic"`
+	Aborted    bool              `json:"aborted"` // атомарный импорт не выполнен из-за невалидных строк
+	Total      int               `json:"total"`
+	Valid      int               `json:"valid"`
+	Created    int               `json:"created"`
+	Duplicates int               `json:"duplicates"`
+	Invalid    int               `json:"invalid"`
+	Failed     int               `json:"failed"`
+	Rows       []ImportRowResult `json:"rows"`
+}
+
+// ImportFormatOf guesses the format by file name or content type ("" if unknown).
+func ImportFormatOf(filename, contentType string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return ImportFormatCSV
+	case ".geojson", ".json":
+		return ImportFormatGeoJSON
+	}
+	switch {
+	case strings.HasPrefix(contentType, "text/csv"):
+		return ImportFormatCSV
+	case strings.HasPrefix(contentType, "application/geo+json"), strings.HasPrefix(contentType, "application/json"):
+		return ImportFormatGeoJSON
+	}
+	return ""
+}
+
+// ParseImport reads organizations from CSV (header required) or a GeoJSON FeatureCollection of points.
+func ParseImport(format string, r io.Reader) ([]ImportRow, error) {
+	switch format {
+	case ImportFormatCSV:
+		return parseImportCSV(r)
+	case ImportFormatGeoJSON:
+		return parseImportGeoJSON(r)
+	}
+	return nil, ErrUnknownImportFormat
+}
+
+func parseImportCSV(r io.Reader) ([]ImportRow, error) {
+	cr, find, err := readCSVHeader(r)
+	if err != nil {
+		return nil, fmt.Errorf("csv header: %w", err)
+	}
+	if find(gazetteerAddressColumns) < 0 || find(importTypeColumns) < 0 {
+		return nil, errors.New("csv header must contain address and organization_type columns")
+	}
+	var rows []ImportRow
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == ImportMaxRows {
+			return nil, ErrImportTooManyRows
+		}
+		rows = append(rows, importRowFrom(len(rows)+1, func(names []string) string {
+			if i := find(names); i >= 0 && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}))
+	}
+	return rows, nil
+}
+
+type geoJSONFeature struct {
+	Type     string `json:"type"`
+	Geometry *struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	} `json:"geometry"`
+	Properties map[string]json.RawMessage `json:"properties"`
+}
+
+func parseImportGeoJSON(r io.Reader) ([]ImportRow, error) {
+	var doc struct {
+		geoJSONFeature
+		Features []json.RawMessage `json:"features"`
+	}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid geojson: %w", err)
+	}
+	var raw []json.RawMessage
+	switch doc.Type {
+	case "FeatureCollection":
+		raw = doc.Features
+	case "Feature":
+		return []ImportRow{geoJSONImportRow(1, doc.geoJSONFeature)}, nil
+	default:
+		return nil, errors.New("geojson must be a FeatureCollection or a Feature")
+	}
+	if len(raw) > ImportMaxRows {
+		return nil, ErrImportTooManyRows
+	}
+	rows := make([]ImportRow, 0, len(raw))
+	for i, fr := range raw {
+		var f geoJSONFeature
+		if err := json.Unmarshal(fr, &f); err != nil {
+			rows = append(rows, ImportRow{Row: i + 1, Problems: []string{"invalid feature: " + err.Error()}})
+			continue
+		}
+		rows = append(rows, geoJSONImportRow(i+1, f))
+	}
+	return rows, nil
+}
+
+// geoJSONImportRow reads properties (strings as is, other values as JSON text); Point geometry overrides lat/lon properties.
+func geoJSONImportRow(n int, f geoJSONFeature) ImportRow {
+	row := importRowFrom(n, func(names []string) string {
+		for _, name := range names {
+			v, ok := f.Properties[name]
+			if !ok || string(v) == "null" {
+				continue
+			}
+			var s string
+			if json.Unmarshal(v, &s) == nil {
+				return strings.TrimSpace(s)
+			}
+			return string(v)
+		}
+		return ""
+	})
+	if f.Geometry == nil {
+		return row
+	}
+	var coords []float64
+	if f.Geometry.Type != "Point" || json.Unmarshal(f.Geometry.Coordinates, &coords) != nil || len(coords) < 2 {
+		row.Problems = append(row.Problems, "geometry must be a Point")
+		return row
+	}
+	lon, lat := coords[0], coords[1]
+	row.Latitude, row.Longitude = &lat, &lon
+	return row
+}
+
+// importRowFrom builds a row from column values; unparsable values are reported in Problems.
+func importRowFrom(n int, get func(names []string) string) ImportRow {
+	row := ImportRow{
+		Row:              n,
+		Name:             get(importNameColumns),
+		Description:      get(importDescriptionColumns),
+		Address:          get(gazetteerAddressColumns),
+		OrganizationType: get(importTypeColumns),
+	}
+	optional := func(names []string) *string {
+		if v := get(names); v != "" {
+			return &v
+		}
+		return nil
+	}
+	row.Phone = optional(importPhoneColumns)
+	row.Website = optional(importWebsiteColumns)
+
+	coordinate := func(names []string, what string) *float64 {
+		v := get(names)
+		if v == "" {
+			return nil
+		}
+		f, err := strconv.ParseFloat(strings.Replace(v, ",", ".", 1), 64)
+		if err != nil {
+			row.Problems = append(row.Problems, fmt.Sprintf("invalid %s %q", what, v))
+			return nil
+		}
+		return &f
+	}
+	row.Latitude = coordinate(gazetteerLatColumns, "latitude")
+	row.Longitude = coordinate(gazetteerLonColumns, "longitude")
+
+	if v := get(importBrandColumns); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil || id == 0 {
+			row.Problems = append(row.Problems, fmt.Sprintf("invalid brand_id %q", v))
+		} else {
+			brandID := uint(id)
+			row.BrandID = &brandID
+		}
+	}
+	hours := func(names []string) *model.OpeningHours {
+		v := get(names)
+		if v == "" {
+			return nil
+		}
+		var h model.OpeningHours
+		if err := json.Unmarshal([]byte(v), &h); err != nil {
+			row.Problems = append(row.Problems, fmt.Sprintf("invalid %s: %v", names[0], err))
+			return nil
+		}
+		return &h
+	}
+	row.OpeningHours = hours(importOpeningHoursColumns)
+	row.QuietHours = hours(importQuietHoursColumns)
+	return row
+}
+
+// importCandidate is an accepted row kept for in-file duplicate detection.
+type importCandidate struct {
+	row      int
+	address  string
+	name     string
+	lat, lon *float64
+}
+
+// Import validates rows, skips duplicates (of existing organizations and of earlier rows) and creates the rest:
+// row by row (failures are reported per row) or, with opts.Atomic, in one transaction only if every row is valid.
+func (s *OrganizationService) Import(rows []ImportRow, opts ImportOptions) (ImportResult, error) {
+	if len(rows) > ImportMaxRows {
+		return ImportResult{}, ErrImportTooManyRows
+	}
+	res := ImportResult{DryRun: opts.DryRun, Atomic: opts.Atomic, Total: len(rows), Rows: make([]ImportRowResult, len(rows))}
+	orgs := make([]*model.Organization, len(rows)) // nil — строка не создаётся
+	types := NewOrganizationTypeService()
+	brands := map[uint]bool{}
+	var accepted []importCandidate
+
+	for i, row := range rows {
+		rr := &res.Rows[i]
+		*rr = ImportRowResult{Row: row.Row, Name: row.Name, Address: row.Address}
+		org, problems, err := s.importOrganization(row, opts.OwnerID, types, brands)
+		if err != nil {
+			return res, err
+		}
+		if len(problems) > 0 {
+			rr.Status, rr.Errors = ImportRowInvalid, problems
+			res.Invalid++
+			continue
+		}
+		cand := importCandidate{row: row.Row, address: org.AddressNormalized, name: importName(org.Name), lat: org.Latitude, lon: org.Longitude}
+		if dupRow, ok := importDuplicateRow(accepted, cand); ok {
+			rr.Status, rr.DuplicateOfRow = ImportRowDuplicate, &dupRow
+			res.Duplicates++
+			continue
+		}
+		dupID, err := importDuplicateOf(cand)
+		if err != nil {
+			return res, err
+		}
+		if dupID != nil {
+			rr.Status, rr.DuplicateOf = ImportRowDuplicate, dupID
+			res.Duplicates++
+			continue
+		}
+		accepted = append(accepted, cand)
+		orgs[i] = org
+		rr.Status = ImportRowValid
+		res.Valid++
+	}
+	if opts.DryRun {
+		return res, nil
+	}
+
+	if opts.Atomic {
+		if res.Invalid > 0 {
+			res.Aborted = true
+			return res, nil
+		}
+		batch := make([]*model.Organization, 0, res.Valid)
+		for _, org := range orgs {
+			if org != nil {
+				batch = append(batch, org)
+			}
+		}
+		if err := repository.CreateOrganizationsWithRevisions(batch, opts.OwnerID); err != nil {
+			return res, err
+		}
+		for i, org := range orgs {
+			if org != nil {
+				res.Rows[i].Status, res.Rows[i].OrganizationID = ImportRowCreated, &org.ID
+				res.Created++
+			}
+		}
+		return res, nil
+	}
+
+	for i, org := range orgs {
+		if org == nil {
+			continue
+		}
+		if err := s.Create(org, opts.OwnerID); err != nil {
+			res.Rows[i].Status, res.Rows[i].Errors = ImportRowFailed, []string{err.Error()}
+			res.Failed++
+			continue
+		}
+		res.Rows[i].Status, res.Rows[i].OrganizationID = ImportRowCreated, &org.ID
+		res.Created++
+	}
+	return res, nil
+}
+
+// importOrganization validates a row like POST /organization does and builds the organization
+// (address normalized, missing coordinates geocoded). brands caches brand existence checks.
+func (s *OrganizationService) importOrganization(row ImportRow, ownerID uint, types *OrganizationTypeService, brands map[uint]bool) (*model.Organization, []string, error) {
+	problems := append([]string(nil), row.Problems...)
+	if utf8.RuneCountInString(row.Name) > 200 {
+		problems = append(problems, "name is longer than 200 characters")
+	}
+	if utf8.RuneCountInString(row.Description) > 5000 {
+		problems = append(problems, "description is longer than 5000 characters")
+	}
+	if row.Phone != nil && utf8.RuneCountInString(*row.Phone) > 50 {
+		problems = append(problems, "phone is longer than 50 characters")
+	}
+	if row.Website != nil {
+		if u, err := url.ParseRequestURI(*row.Website); err != nil || u.Scheme == "" || u.Host == "" || len(*row.Website) > 500 {
+			problems = append(problems, fmt.Sprintf("invalid website %q", *row.Website))
+		}
+	}
+	normalized := model.NormalizeAddress(row.Address)
+	if normalized == "" {
+		problems = append(problems, "address is required")
+	}
+	switch {
+	case (row.Latitude == nil) != (row.Longitude == nil):
+		problems = append(problems, "latitude and longitude must be given together")
+	case row.Latitude != nil && (*row.Latitude < -90 || *row.Latitude > 90 || *row.Longitude < -180 || *row.Longitude > 180):
+		problems = append(problems, "coordinates out of range")
+	}
+	for _, f := range []struct {
+		name  string
+		hours *model.OpeningHours
+	}{{"opening_hours", row.OpeningHours}, {"quiet_hours", row.QuietHours}} {
+		if f.hours == nil {
+			continue
+		}
+		if err := f.hours.Validate(); err != nil {
+			problems = append(problems, f.name+": "+err.Error())
+		}
+	}
+
+	var orgType string
+	if row.OrganizationType == "" {
+		problems = append(problems, "organization_type is required")
+	} else {
+		t, err := types.Resolve(row.OrganizationType)
+		switch {
+		case errors.Is(err, ErrUnknownOrganizationType):
+			problems = append(problems, fmt.Sprintf("unknown organization type %q", row.OrganizationType))
+		case err != nil:
+			return nil, nil, err
+		}
+		orgType = t
+	}
+	if row.BrandID != nil {
+		exists, checked := brands[*row.BrandID]
+		if !checked {
+			_, err := repository.GetBrand(*row.BrandID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, nil, err
+			}
+			exists = err == nil
+			brands[*row.BrandID] = exists
+		}
+		if !exists {
+			problems = append(problems, fmt.Sprintf("brand %d not found", *row.BrandID))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, problems, nil
+	}
+
+	org := &model.Organization{
+		OwnerID:           ownerID,
+		Name:              row.Name,
+		Description:       row.Description,
+		Phone:             row.Phone,
+		Website:           row.Website,
+		OpeningHours:      row.OpeningHours,
+		QuietHours:        row.QuietHours,
+		Address:           row.Address,
+		AddressNormalized: normalized,
+		Latitude:          row.Latitude,
+		Longitude:         row.Longitude,
+		OrganizationType:  orgType,
+		BrandID:           row.BrandID,
+		Status:            model.OrganizationStatusActive,
+	}
+	if org.Latitude == nil {
+		if lat, lon, ok := geocodeAddress(org.Address); ok {
+			org.Latitude, org.Longitude = &lat, &lon
+		}
+	}
+	return org, nil, nil
+}
+
+// importName is the name form used to compare duplicates.
+func importName(name string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(strings.ToLower(name), "ё", "е")), " ")
+}
+
+// importSameVenue: the same normalized address or points within importDuplicateRadius, and the same name
+// when both are named — so different venues in one building (mall, business center) are not merged.
+func importSameVenue(a, b importCandidate) bool {
+	if a.name != "" && b.name != "" && a.name != b.name {
+		return false
+	}
+	if a.address == b.address {
+		return true
+	}
+	return a.lat != nil && b.lat != nil && distanceMeters(*a.lat, *a.lon, *b.lat, *b.lon) <= importDuplicateRadius
+}
+
+func importDuplicateRow(accepted []importCandidate, c importCandidate) (int, bool) {
+	for _, a := range accepted {
+		if importSameVenue(a, c) {
+			return a.row, true
+		}
+	}
+	return 0, false
+}
+
+// importDuplicateOf returns the id of an existing organization the candidate duplicates (nil if none).
+func importDuplicateOf(c importCandidate) (*uint, error) {
+	existing, err := repository.ListOrganizationsByNormalizedAddress(c.address)
+	if err != nil {
+		return nil, err
+	}
+	if c.lat != nil {
+		near, err := repository.ListOrganizationsNear(*c.lat, *c.lon, importDuplicateRadius)
+		if err != nil {
+			return nil, err
+		}
+		existing = append(existing, near...)
+	}
+	for _, o := range existing {
+		if importSameVenue(importCandidate{address: o.AddressNormalized, name: importName(o.Name), lat: o.Latitude, lon: o.Longitude}, c) {
+			id := o.ID
+			return &id, nil
+		}
+	}
+	return nil, nil
+}
+
+// distanceMeters is the haversine distance between two points.
+func distanceMeters(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadius = 6371000.0
+	rad := math.Pi / 180
+	dLat, dLon := (lat2-lat1)*rad, (lon2-lon1)*rad
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
@@ -3,7 +3,6 @@ package service
 import (
 	"errors"
 	"fmt"
-	"strings"
 
 	"2gis-calm-map/api/internal/model"
 	"2gis-calm-map/api/internal/repository"
@@ -11,6 +10,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// ErrUnknownParam is returned for param names outside model.ParamNames.
+var ErrUnknownParam = errors.New("unknown param")
+
 type OrganizationParamsService struct{}
 
 func NewOrganizationParamsService() *OrganizationParamsService { return &OrganizationParamsService{} }
@@ -27,50 +29,166 @@ func (s *OrganizationParamsService) GetOrCreate(orgID uint) (model.OrganizationP
 	return p, nil
 }
 
-// ComputeAverageAcross returns (avg(param1)+avg(param2)+...)/n for provided param names.
-func (s *OrganizationParamsService) ComputeAverageAcross(p model.OrganizationParams, params []string) (float64, error) {
-	if len(params) == 0 {
-		return 0, fmt.Errorf("no params provided")
+// Get returns existing params or zero aggregates without writing (for public reads).
+func (s *OrganizationParamsService) Get(orgID uint) (model.OrganizationParams, error) {
+	p, err := repository.GetOrganizationParams(orgID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.OrganizationParams{OrganizationID: orgID}, nil
+		}
+		return model.OrganizationParams{}, err
 	}
-	var sum float64
-	var counted int
-	addIfPositive := func(val float64) {
-		if val > 0 { // игнорируем нули как просили
-			sum += val
-			counted++
+	return p, nil
+}
+
+// Reasons a param was excluded from a combined average.
+const (
+	ExcludedNotRated   = "not_rated"   // по параметру ещё нет оценок (avg = 0)
+	ExcludedZeroWeight = "zero_weight" // вес параметра равен 0
+)
+
+// ParamBreakdown explains how a single param contributed to a combined average.
+type ParamBreakdown struct {
+	Param    string  `json:"param"`
+	Avg      float64 `json:"avg"`
+	Count    uint    `json:"count"`
+	Weight   float64 `json:"weight"`
+	Included bool    `json:"included"`
+	Reason   string  `json:"reason,omitempty"` // not_rated | zero_weight (only when excluded)
+	// Percentile среди организаций того же типа (0..100); nil если не рассчитан
+	Percentile *float64 `json:"percentile,omitempty"`
+}
+
+// ExplainAverage computes sum(w*avg)/sum(w) over rated params and reports each param's contribution.
+// With weights == nil every entry of params gets weight 1 (repeated names add up); otherwise params is ignored.
+func (s *OrganizationParamsService) ExplainAverage(p model.OrganizationParams, params []string, weights map[string]float64) (float64, []ParamBreakdown, error) {
+	if weights == nil {
+		if len(params) == 0 {
+			return 0, nil, fmt.Errorf("no params provided")
+		}
+		weights = make(map[string]float64, len(params))
+		for _, raw := range params {
+			weights[raw]++
+		}
+	} else {
+		if len(weights) == 0 {
+			return 0, nil, fmt.Errorf("no weights provided")
+		}
+		params = nil
+	}
+
+	// нормализуем имена и складываем веса синонимов (staffattitude/staff_attitude)
+	norm := make(map[string]float64, len(weights))
+	for raw, w := range weights {
+		name, ok := model.NormalizeParamName(raw)
+		if !ok {
+			return 0, nil, fmt.Errorf("%w: %s", ErrUnknownParam, raw)
+		}
+		if w < 0 {
+			return 0, nil, fmt.Errorf("negative weight for %s", raw)
 		}
+		norm[name] += w
 	}
+	// порядок: как в запросе для params, иначе как в model.ParamNames
+	var order []string
+	seen := make(map[string]bool, len(norm))
 	for _, raw := range params {
-		name := strings.ToLower(raw)
-		switch name {
-		case "appearance":
-			addIfPositive(p.AppearanceAvg)
-		case "lighting":
-			addIfPositive(p.LightingAvg)
-		case "smell":
-			addIfPositive(p.SmellAvg)
-		case "temperature":
-			addIfPositive(p.TemperatureAvg)
-		case "tactility":
-			addIfPositive(p.TactilityAvg)
-		case "signage":
-			addIfPositive(p.SignageAvg)
-		case "intuitiveness":
-			addIfPositive(p.IntuitivenessAvg)
-		case "staffattitude", "staff_attitude":
-			addIfPositive(p.StaffAttitudeAvg)
-		case "peopledensity", "people_density":
-			addIfPositive(p.PeopleDensityAvg)
-		case "selfservice", "self_service":
-			addIfPositive(p.SelfServiceAvg)
-		case "calmness":
-			addIfPositive(p.CalmnessAvg)
+		name, _ := model.NormalizeParamName(raw)
+		if !seen[name] {
+			seen[name] = true
+			order = append(order, name)
+		}
+	}
+	for _, name := range model.ParamNames {
+		if _, ok := norm[name]; ok && !seen[name] {
+			order = append(order, name)
+		}
+	}
+
+	var sum, wsum float64
+	breakdown := make([]ParamBreakdown, 0, len(order))
+	for _, name := range order {
+		avg, count, _ := p.Factor(name)
+		b := ParamBreakdown{Param: name, Avg: avg, Count: count, Weight: norm[name]}
+		switch {
+		case avg <= 0: // игнорируем нули как просили
+			b.Reason = ExcludedNotRated
+		case b.Weight == 0:
+			b.Reason = ExcludedZeroWeight
 		default:
-			return 0, fmt.Errorf("unknown param: %s", raw)
+			b.Included = true
+			sum += b.Weight * avg
+			wsum += b.Weight
 		}
+		breakdown = append(breakdown, b)
+	}
+	if wsum == 0 {
+		return 0, breakdown, nil // все выбранные параметры имели среднее 0
 	}
-	if counted == 0 {
-		return 0, nil // все выбранные параметры имели среднее 0
+	return sum / wsum, breakdown, nil
+}
+
+// ComputeAverageAcross returns (avg(param1)+avg(param2)+...)/n for provided param names.
+func (s *OrganizationParamsService) ComputeAverageAcross(p model.OrganizationParams, params []string) (float64, error) {
+	avg, _, err := s.ExplainAverage(p, params, nil)
+	return avg, err
+}
+
+// NormalizeParams converts param names to canonical form, rejecting unknown ones.
+func (s *OrganizationParamsService) NormalizeParams(params []string) ([]string, error) {
+	out := make([]string, 0, len(params))
+	for _, raw := range params {
+		name, ok := model.NormalizeParamName(raw)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownParam, raw)
+		}
+		out = append(out, name)
+	}
+	return out, nil
+}
+
+// SearchByType filters, sorts and pages organizations of a type with a single read-only query.
+func (s *OrganizationParamsService) SearchByType(f repository.OrganizationAverageFilter) ([]repository.OrganizationAverageRow, error) {
+	if len(f.Params) == 0 {
+		return nil, fmt.Errorf("no params provided")
 	}
-	return sum / float64(counted), nil
+	params, err := s.NormalizeParams(f.Params)
+	if err != nil {
+		return nil, err
+	}
+	f.Params = params
+	mins := make(map[string]float64, len(f.MinParams))
+	for raw, v := range f.MinParams {
+		name, ok := model.NormalizeParamName(raw)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownParam, raw)
+		}
+		mins[name] = v
+	}
+	f.MinParams = mins
+	return repository.SearchOrganizationAverages(f)
+}
+
+// BestWorstFactors returns rated params (avg > 0) with the highest and lowest average; ok=false if nothing is rated.
+func (s *OrganizationParamsService) BestWorstFactors(p model.OrganizationParams) (best, worst string, ok bool) {
+	var bestAvg, worstAvg float64
+	for _, name := range model.ParamNames {
+		avg, _, _ := p.Factor(name)
+		if avg <= 0 {
+			continue
+		}
+		if !ok || avg > bestAvg {
+			best, bestAvg = name, avg
+		}
+		if !ok || avg < worstAvg {
+			worst, worstAvg = name, avg
+		}
+		ok = true
+	}
+	return best, worst, ok
+}
+
+// BackfillSensitivity computes weighted aggregates for organizations reviewed before they were introduced.
+func (s *OrganizationParamsService) BackfillSensitivity() error {
+	return repository.BackfillSensitivityAggregates(sensitiveWeight)
 }
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/repository"
+)
+
+type OrganizationPercentileService struct{}
+
+func NewOrganizationPercentileService() *OrganizationPercentileService {
+	return &OrganizationPercentileService{}
+}
+
+// параметры по типам организаций, чьи агрегаты изменились после последнего пересчёта перцентилей
+var percentileDirty = struct {
+	sync.Mutex
+	params map[string]map[string]bool
+}{params: map[string]map[string]bool{}}
+
+// MarkDirty schedules recomputation of the given params of a type on the next worker tick, so bursts
+// of reviews of one type cost a single refresh.
+func (s *OrganizationPercentileService) MarkDirty(orgType string, params []string) {
+	if len(params) == 0 {
+		return
+	}
+	percentileDirty.Lock()
+	defer percentileDirty.Unlock()
+	if percentileDirty.params[orgType] == nil {
+		percentileDirty.params[orgType] = map[string]bool{}
+	}
+	for _, p := range params {
+		percentileDirty.params[orgType][p] = true
+	}
+}
+
+// RunWorker rebuilds everything once, then every interval refreshes only dirty params. Blocks; run in a goroutine.
+func (s *OrganizationPercentileService) RunWorker(interval time.Duration) {
+	if err := s.RebuildAll(); err != nil {
+		log.Println("warn: failed to rebuild percentiles:", err)
+	}
+	for {
+		time.Sleep(interval)
+		percentileDirty.Lock()
+		dirty := percentileDirty.params
+		percentileDirty.params = map[string]map[string]bool{}
+		percentileDirty.Unlock()
+		for orgType, set := range dirty {
+			params := make([]string, 0, len(set))
+			for p := range set {
+				params = append(params, p)
+			}
+			if err := repository.RefreshPercentiles(orgType, params); err != nil {
+				log.Println("warn: percentile refresh failed for type", orgType, err)
+				s.MarkDirty(orgType, params) // попробуем на следующем тике
+			}
+		}
+	}
+}
+
+// RebuildAll recomputes every param for every type (startup / after bulk changes).
+func (s *OrganizationPercentileService) RebuildAll() error {
+	types, err := repository.ListOrganizationTypes()
+	if err != nil {
+		return err
+	}
+	for _, t := range types {
+		if err := repository.RefreshPercentiles(t, model.ParamNames); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForOrganizations returns organization_id -> param -> percentile.
+func (s *OrganizationPercentileService) ForOrganizations(ids []uint) (map[uint]map[string]float64, error) {
+	list, err := repository.ListPercentiles(ids)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[uint]map[string]float64, len(ids))
+	for _, p := range list {
+		if out[p.OrganizationID] == nil {
+			out[p.OrganizationID] = map[string]float64{}
+		}
+		out[p.OrganizationID][p.Param] = p.Percentile
+	}
+	return out, nil
+}
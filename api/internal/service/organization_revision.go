@@ -0,0 +1,71 @@
+package service
+
+import (
+	"errors"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/repository"
+)
+
+// ErrRevisionTypeUnknown: the revision's organization_type was renamed or removed from the taxonomy since.
+var ErrRevisionTypeUnknown = errors.New("organization type of the revision is no longer in the taxonomy (see GET /organization-types)")
+
+type OrganizationRevisionService struct{}
+
+func NewOrganizationRevisionService() *OrganizationRevisionService {
+	return &OrganizationRevisionService{}
+}
+
+// List returns revisions newest first with Changed filled.
+func (s *OrganizationRevisionService) List(orgID uint, limit, offset int) ([]model.OrganizationRevision, error) {
+	list, err := repository.ListOrganizationRevisions(orgID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	for i := range list {
+		list[i].Changed = model.ChangedFields(list[i].Before, list[i].After)
+	}
+	return list, nil
+}
+
+// Revert restores the organization to the state right after the given revision and records a "revert" revision.
+func (s *OrganizationRevisionService) Revert(orgID, revisionID, actorID uint) (model.Organization, error) {
+	rev, err := repository.GetOrganizationRevision(orgID, revisionID)
+	if err != nil {
+		return model.Organization{}, err
+	}
+	before, err := repository.GetOrganizationByID(orgID)
+	if err != nil {
+		return before, err
+	}
+	if rev.After == nil {
+		return before, nil
+	}
+	updates := rev.After.Updates()
+	// тип мог быть переименован или удалён из справочника после ревизии — восстанавливаем только известный slug
+	if raw, ok := updates["organization_type"].(string); ok {
+		slug, err := NewOrganizationTypeService().Resolve(raw)
+		if errors.Is(err, ErrUnknownOrganizationType) {
+			return before, ErrRevisionTypeUnknown
+		}
+		if err != nil {
+			return before, err
+		}
+		updates["organization_type"] = slug
+	}
+	org, err := repository.UpdateOrganizationWithRevision(orgID, updates, &model.OrganizationRevision{
+		ActorID:    actorID,
+		Action:     model.RevisionActionRevert,
+		Before:     model.SnapshotOf(before),
+		RevertedTo: &rev.ID,
+	})
+	if err != nil {
+		return before, err
+	}
+	if org.OrganizationType != before.OrganizationType {
+		refreshRankings(before.OrganizationType, org.OrganizationType)
+	} else if org.Status != before.Status {
+		refreshRankings(org.OrganizationType)
+	}
+	return org, nil
+}
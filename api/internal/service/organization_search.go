@@ -0,0 +1,39 @@
+package service
+
+import (
+	"fmt"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/repository"
+)
+
+type OrganizationSearchService struct{}
+
+func NewOrganizationSearchService() *OrganizationSearchService { return &OrganizationSearchService{} }
+
+// Search runs full-text search; params and min_params are normalized to canonical names.
+func (s *OrganizationSearchService) Search(q repository.OrganizationSearchQuery) ([]repository.OrganizationSearchRow, error) {
+	q, err := normalizeSearchQuery(q)
+	if err != nil {
+		return nil, err
+	}
+	return repository.SearchOrganizations(q)
+}
+
+func normalizeSearchQuery(q repository.OrganizationSearchQuery) (repository.OrganizationSearchQuery, error) {
+	params, err := NewOrganizationParamsService().NormalizeParams(q.Params)
+	if err != nil {
+		return q, err
+	}
+	q.Params = params
+	mins := make(map[string]float64, len(q.MinParams))
+	for raw, v := range q.MinParams {
+		name, ok := model.NormalizeParamName(raw)
+		if !ok {
+			return q, fmt.Errorf("%w: %s", ErrUnknownParam, raw)
+		}
+		mins[name] = v
+	}
+	q.MinParams = mins
+	return q, nil
+}
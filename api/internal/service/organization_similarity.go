@@ -0,0 +1,91 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// ErrNoCoordinates is returned when radius filter is requested for an organization without coordinates.
+var ErrNoCoordinates = errors.New("organization has no coordinates")
+
+// Минимальное число оценённых параметров у кандидата, чтобы сравнение было осмысленным.
+const similarMinRatedParams = 3
+
+type OrganizationSimilarityService struct{}
+
+func NewOrganizationSimilarityService() *OrganizationSimilarityService {
+	return &OrganizationSimilarityService{}
+}
+
+// SimilarOptions configures Similar.
+type SimilarOptions struct {
+	Metric   string     // euclidean (default) | cosine
+	SameType bool       // только организации того же типа
+	Radius   float64    // метры, 0 = без ограничения
+	OpenAt   *time.Time // только открытые в этот момент
+	QuietAt  *time.Time // только в «тихие часы» в этот момент
+	Limit    int
+}
+
+// Refresh recomputes precomputed vector after aggregates change.
+func (s *OrganizationSimilarityService) Refresh(p model.OrganizationParams) error {
+	v := model.VectorFromParams(p)
+	return repository.UpsertOrganizationVector(&v)
+}
+
+// BackfillMissing builds vectors for aggregates created before vectors existed.
+func (s *OrganizationSimilarityService) BackfillMissing() (int, error) {
+	list, err := repository.ListOrganizationParamsWithoutVector()
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range list {
+		if err := s.Refresh(p); err != nil {
+			return 0, err
+		}
+	}
+	return len(list), nil
+}
+
+// Similar returns organizations whose factor vectors are closest to org's one.
+func (s *OrganizationSimilarityService) Similar(org model.Organization, opts SimilarOptions) ([]repository.SimilarRow, error) {
+	src, err := repository.GetOrganizationVector(org.ID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		// вектора ещё нет — считаем на лету, без записи
+		p := model.OrganizationParams{OrganizationID: org.ID}
+		if org.Params != nil {
+			p = *org.Params
+		}
+		src = model.VectorFromParams(p)
+	}
+	if src.RatedCount == 0 {
+		return []repository.SimilarRow{}, nil
+	}
+
+	q := repository.SimilarQuery{
+		Source:   src,
+		Metric:   opts.Metric,
+		MinRated: similarMinRatedParams,
+		OpenAt:   opts.OpenAt,
+		QuietAt:  opts.QuietAt,
+		Limit:    opts.Limit,
+	}
+	if opts.SameType {
+		q.SameType = org.OrganizationType
+	}
+	if opts.Radius > 0 {
+		if org.Latitude == nil || org.Longitude == nil {
+			return nil, ErrNoCoordinates
+		}
+		q.Latitude, q.Longitude, q.Radius = org.Latitude, org.Longitude, opts.Radius
+	}
+	return repository.FindSimilarOrganizations(q)
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// ErrUnknownOrganizationType is returned when a type matches neither a slug nor a localized name.
+var ErrUnknownOrganizationType = errors.New("unknown organization type")
+
+type OrganizationTypeService struct{}
+
+func NewOrganizationTypeService() *OrganizationTypeService { return &OrganizationTypeService{} }
+
+// Resolve maps user input ("Cafe", "кафе", "cafe") to the taxonomy slug.
+func (s *OrganizationTypeService) Resolve(raw string) (string, error) {
+	t, err := repository.FindOrganizationType(model.SlugifyOrganizationType(raw), strings.TrimSpace(raw))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrUnknownOrganizationType
+		}
+		return "", err
+	}
+	return t.Slug, nil
+}
+
+// ResolveOrRaw is Resolve for read paths: unknown input is passed through unchanged.
+func (s *OrganizationTypeService) ResolveOrRaw(raw string) string {
+	if slug, err := s.Resolve(raw); err == nil {
+		return slug
+	}
+	return raw
+}
+
+func (s *OrganizationTypeService) List() ([]repository.OrganizationTypeCount, error) {
+	return repository.ListOrganizationTypesWithCounts()
+}
+
+// Sync seeds the default taxonomy and migrates free-form organization_type values to slugs.
+// Values that match nothing become new taxonomy entries (without parent category).
+func (s *OrganizationTypeService) Sync() error {
+	seeded, err := repository.SeedOrganizationTypes(model.DefaultOrganizationTypes)
+	if err != nil {
+		return err
+	}
+	unknown, err := repository.ListUnknownOrganizationTypes()
+	if err != nil {
+		return err
+	}
+	for _, raw := range unknown {
+		slug, err := s.Resolve(raw)
+		if errors.Is(err, ErrUnknownOrganizationType) {
+			slug = model.SlugifyOrganizationType(raw)
+			if slug == "" {
+				slug = "other"
+			}
+			name := strings.TrimSpace(raw)
+			if name == "" {
+				name = slug
+			}
+			_, err = repository.SeedOrganizationTypes([]model.OrganizationType{{Slug: slug, NameRu: name, NameEn: name}})
+		}
+		if err != nil {
+			return err
+		}
+		if err := repository.RenameOrganizationsType(raw, slug); err != nil {
+			return err
+		}
+		log.Printf("organization type %q migrated to %q", raw, slug)
+	}
+	if seeded > 0 {
+		// новые названия типов должны попасть в полнотекстовый индекс
+		return repository.RefreshOrganizationSearchVectors()
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"2gis-calm-map/api/internal/model"
+)
+
+// ErrNoQuietHours is returned when the organization has not published quiet hours.
+var ErrNoQuietHours = errors.New("organization has no quiet hours")
+
+var icalWeekdays = [...]string{"", "MO", "TU", "WE", "TH", "FR", "SA", "SU"}
+
+const icalLocalLayout = "20060102T150405"
+
+// quietHoursEpoch (a Monday) anchors weekly events, so their DTSTART does not change from request to request.
+var quietHoursEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// vtimezoneYears: offset transitions are listed this far ahead of now.
+const vtimezoneYears = 5
+
+// QuietHoursCalendar renders organization quiet hours as an iCalendar feed (RFC 5545):
+// a weekly recurring event per slot starting in the week of quietHoursEpoch (dates with exceptions excluded)
+// plus single events for exception intervals; local times refer to a VTIMEZONE of the organization's timezone.
+func QuietHoursCalendar(org model.Organization, now time.Time) (string, error) {
+	h := org.QuietHours
+	if h == nil || (len(h.Weekly) == 0 && len(h.Exceptions) == 0) {
+		return "", ErrNoQuietHours
+	}
+	tz := h.Timezone
+	if tz == "" {
+		tz = model.DefaultTimezone
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return "", err
+	}
+	epoch := time.Date(quietHoursEpoch.Year(), quietHoursEpoch.Month(), quietHoursEpoch.Day(), 0, 0, 0, 0, loc)
+	title := org.Name
+	if title == "" {
+		title = org.Address
+	}
+	summary := "Тихие часы"
+	if title != "" {
+		summary += ": " + title
+	}
+	stamp := now.UTC().Format("20060102T150405Z")
+
+	// даты исключений по дням недели — на них еженедельное правило не действует
+	exceptionDates := map[int][]time.Time{}
+	since := epoch // начало описания часового пояса — не позже самого раннего события
+	for _, e := range h.Exceptions {
+		d, err := time.ParseInLocation("2006-01-02", e.Date, loc)
+		if err != nil {
+			continue
+		}
+		exceptionDates[isoWeekday(d)] = append(exceptionDates[isoWeekday(d)], d)
+		if d.Before(since) {
+			since = d
+		}
+	}
+
+	var w calendarWriter
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:-//2gis-calm-map//quiet-hours//RU")
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	w.line("X-WR-CALNAME:" + icalText(summary))
+	w.line("X-WR-TIMEZONE:" + tz)
+	writeVTimezone(&w, tz, loc, since, now.AddDate(vtimezoneYears, 0, 0))
+
+	event := func(uid string, start, end time.Time, extra ...string) {
+		w.line("BEGIN:VEVENT")
+		w.line(fmt.Sprintf("UID:quiet-%d-%s@2gis-calm-map", org.ID, uid))
+		w.line("DTSTAMP:" + stamp)
+		w.line("DTSTART;TZID=" + tz + ":" + start.Format(icalLocalLayout))
+		w.line("DTEND;TZID=" + tz + ":" + end.Format(icalLocalLayout))
+		for _, l := range extra {
+			w.line(l)
+		}
+		w.line("SUMMARY:" + icalText(summary))
+		if org.Address != "" {
+			w.line("LOCATION:" + icalText(org.Address))
+		}
+		w.line("END:VEVENT")
+	}
+
+	for i, slot := range h.Weekly {
+		if slot.Day < 1 || slot.Day > 7 {
+			continue
+		}
+		first := epoch.AddDate(0, 0, slot.Day-isoWeekday(epoch))
+		start, end := clockOn(first, slot.Opens), clockOn(first, slot.Closes)
+		if !end.After(start) {
+			end = end.AddDate(0, 0, 1) // через полночь
+		}
+		extra := []string{"RRULE:FREQ=WEEKLY;BYDAY=" + icalWeekdays[slot.Day]}
+		for _, d := range exceptionDates[slot.Day] {
+			extra = append(extra, "EXDATE;TZID="+tz+":"+clockOn(d, slot.Opens).Format(icalLocalLayout))
+		}
+		event(fmt.Sprintf("w%d", i), start, end, extra...)
+	}
+	for i, e := range h.Exceptions {
+		d, err := time.ParseInLocation("2006-01-02", e.Date, loc)
+		if err != nil || e.Closed {
+			continue
+		}
+		for j, iv := range e.Intervals {
+			start, end := clockOn(d, iv.Opens), clockOn(d, iv.Closes)
+			if !end.After(start) {
+				end = end.AddDate(0, 0, 1)
+			}
+			var extra []string
+			if e.Note != "" {
+				extra = append(extra, "DESCRIPTION:"+icalText(e.Note))
+			}
+			event(fmt.Sprintf("e%d-%d", i, j), start, end, extra...)
+		}
+	}
+	w.line("END:VCALENDAR")
+	return w.String(), nil
+}
+
+// writeVTimezone describes loc as TZID tz between from and until: an observance for the offset in effect at from
+// and one per offset transition (DAYLIGHT for DST periods), each onset given in the preceding offset.
+func writeVTimezone(w *calendarWriter, tz string, loc *time.Location, from, until time.Time) {
+	w.line("BEGIN:VTIMEZONE")
+	w.line("TZID:" + tz)
+	t := from.In(loc)
+	name, offset := t.Zone()
+	writeObservance(w, t.IsDST(), t.Format(icalLocalLayout), offset, offset, name)
+	for {
+		_, end := t.ZoneBounds()
+		if end.IsZero() || !end.After(t) || !end.Before(until) {
+			break
+		}
+		t = end.In(loc)
+		next, nextOffset := t.Zone()
+		onset := t.UTC().Add(time.Duration(offset) * time.Second).Format(icalLocalLayout)
+		writeObservance(w, t.IsDST(), onset, offset, nextOffset, next)
+		offset = nextOffset
+	}
+	w.line("END:VTIMEZONE")
+}
+
+func writeObservance(w *calendarWriter, dst bool, onset string, from, to int, name string) {
+	kind := "STANDARD"
+	if dst {
+		kind = "DAYLIGHT"
+	}
+	w.line("BEGIN:" + kind)
+	w.line("DTSTART:" + onset)
+	w.line("TZOFFSETFROM:" + icalOffset(from))
+	w.line("TZOFFSETTO:" + icalOffset(to))
+	w.line("TZNAME:" + icalText(name))
+	w.line("END:" + kind)
+}
+
+// icalOffset formats a UTC offset in seconds as ±HHMM (±HHMMSS if seconds are not zero).
+func icalOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign, seconds = "-", -seconds
+	}
+	s := fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds/60%60)
+	if seconds%60 != 0 {
+		s += fmt.Sprintf("%02d", seconds%60)
+	}
+	return s
+}
+
+func isoWeekday(t time.Time) int {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+	return int(t.Weekday())
+}
+
+// clockOn returns the date at local "HH:MM" ("24:00" — midnight of the next day).
+func clockOn(date time.Time, hhmm string) time.Time {
+	var hh, mm int
+	fmt.Sscanf(hhmm, "%d:%d", &hh, &mm)
+	return time.Date(date.Year(), date.Month(), date.Day(), hh, mm, 0, 0, date.Location())
+}
+
+// icalText escapes a TEXT property value.
+func icalText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// calendarWriter joins content lines with CRLF and folds them at 75 octets without splitting UTF-8 runes.
+type calendarWriter struct{ b strings.Builder }
+
+func (w *calendarWriter) line(s string) {
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.b.WriteString(s[:cut])
+		w.b.WriteString("\r\n ")
+		s = s[cut:]
+		limit = 74 // продолжение начинается с пробела
+	}
+	w.b.WriteString(s)
+	w.b.WriteString("\r\n")
+}
+
+func (w *calendarWriter) String() string { return w.b.String() }
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"2gis-calm-map/api/internal/model"
+)
+
+// unfoldCalendar joins folded content lines and splits the feed into lines.
+func unfoldCalendar(t *testing.T, cal string) []string {
+	t.Helper()
+	if !strings.HasSuffix(cal, "\r\n") {
+		t.Fatalf("calendar does not end with CRLF: %q", cal)
+	}
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(cal, "\r\n ", ""), "\r\n"), "\r\n")
+}
+
+func TestQuietHoursCalendar(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		hours   model.OpeningHours
+		want    []string // строки, которые должны быть в ленте
+		notWant []string
+	}{
+		{
+			name:  "weekly slot anchored to the epoch week",
+			hours: model.OpeningHours{Weekly: []model.WeeklyOpening{{Day: 1, Opens: "10:00", Closes: "12:00"}}},
+			want: []string{
+				"X-WR-TIMEZONE:Europe/Moscow",
+				"UID:quiet-7-w0@2gis-calm-map",
+				"DTSTAMP:20260301T120000Z",
+				"DTSTART;TZID=Europe/Moscow:20240101T100000",
+				"DTEND;TZID=Europe/Moscow:20240101T120000",
+				"RRULE:FREQ=WEEKLY;BYDAY=MO",
+				`SUMMARY:Тихие часы: Кафе «Тишина»`,
+				`LOCATION:ул. Ленина\, 5`,
+			},
+			notWant: []string{"EXDATE;TZID=Europe/Moscow:20240101T100000"},
+		},
+		{
+			name: "overnight, 24:00 and round-the-clock slots end on the next day",
+			hours: model.OpeningHours{Timezone: "Asia/Yekaterinburg", Weekly: []model.WeeklyOpening{
+				{Day: 5, Opens: "22:00", Closes: "02:00"},
+				{Day: 7, Opens: "20:00", Closes: "24:00"},
+				{Day: 3, Opens: "00:00", Closes: "00:00"},
+				{Day: 8, Opens: "10:00", Closes: "11:00"}, // несуществующий день пропускается
+			}},
+			want: []string{
+				"DTSTART;TZID=Asia/Yekaterinburg:20240105T220000",
+				"DTEND;TZID=Asia/Yekaterinburg:20240106T020000",
+				"RRULE:FREQ=WEEKLY;BYDAY=FR",
+				"DTSTART;TZID=Asia/Yekaterinburg:20240107T200000",
+				"DTEND;TZID=Asia/Yekaterinburg:20240108T000000",
+				"RRULE:FREQ=WEEKLY;BYDAY=SU",
+				"DTSTART;TZID=Asia/Yekaterinburg:20240103T000000",
+				"DTEND;TZID=Asia/Yekaterinburg:20240104T000000",
+				"TZOFFSETTO:+0500",
+			},
+			notWant: []string{"UID:quiet-7-w3@2gis-calm-map"},
+		},
+		{
+			name: "exception dates are excluded from the weekly rule of their weekday",
+			hours: model.OpeningHours{
+				Weekly: []model.WeeklyOpening{
+					{Day: 1, Opens: "10:00", Closes: "12:00"},
+					{Day: 2, Opens: "18:00", Closes: "19:00"},
+				},
+				Exceptions: []model.OpeningException{
+					{Date: "2026-03-09", Closed: true, Intervals: []model.TimeInterval{{Opens: "10:00", Closes: "11:00"}}},
+					{Date: "2026-03-10", Intervals: []model.TimeInterval{{Opens: "14:00", Closes: "15:00"}, {Opens: "23:00", Closes: "01:00"}}, Note: "Сокращённый день; без музыки"},
+					{Date: "10.03.2026"}, // некорректная дата пропускается
+				},
+			},
+			want: []string{
+				"EXDATE;TZID=Europe/Moscow:20260309T100000",
+				"EXDATE;TZID=Europe/Moscow:20260310T180000",
+				"UID:quiet-7-e1-0@2gis-calm-map",
+				"DTSTART;TZID=Europe/Moscow:20260310T140000",
+				"DTEND;TZID=Europe/Moscow:20260310T150000",
+				"DTSTART;TZID=Europe/Moscow:20260310T230000",
+				"DTEND;TZID=Europe/Moscow:20260311T010000",
+				`DESCRIPTION:Сокращённый день\; без музыки`,
+			},
+			notWant: []string{
+				"UID:quiet-7-e0-0@2gis-calm-map",
+				"EXDATE;TZID=Europe/Moscow:20260309T180000",
+				"EXDATE;TZID=Europe/Moscow:20260310T100000",
+			},
+		},
+		{
+			name: "exception before the epoch moves the start of VTIMEZONE",
+			hours: model.OpeningHours{Timezone: "Europe/Berlin", Exceptions: []model.OpeningException{
+				{Date: "2023-07-01", Intervals: []model.TimeInterval{{Opens: "09:00", Closes: "10:00"}}},
+			}},
+			want: []string{
+				"DTSTART:20230701T000000",
+				"TZNAME:CEST",
+				"DTSTART;TZID=Europe/Berlin:20230701T090000",
+			},
+			notWant: []string{"RRULE:FREQ=WEEKLY;BYDAY=SA"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hours := tt.hours
+			org := model.Organization{ID: 7, Name: "Кафе «Тишина»", Address: "ул. Ленина, 5", QuietHours: &hours}
+			cal, err := QuietHoursCalendar(org, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines := unfoldCalendar(t, cal)
+			if lines[0] != "BEGIN:VCALENDAR" || lines[len(lines)-1] != "END:VCALENDAR" {
+				t.Errorf("calendar is not wrapped in VCALENDAR: %q … %q", lines[0], lines[len(lines)-1])
+			}
+			has := map[string]bool{}
+			for _, l := range lines {
+				has[l] = true
+			}
+			for _, l := range tt.want {
+				if !has[l] {
+					t.Errorf("missing line %q in\n%s", l, cal)
+				}
+			}
+			for _, l := range tt.notWant {
+				if has[l] {
+					t.Errorf("unexpected line %q", l)
+				}
+			}
+		})
+	}
+}
+
+func TestQuietHoursCalendarErrors(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	for _, h := range []*model.OpeningHours{nil, {}, {Timezone: "Europe/Berlin"}} {
+		if _, err := QuietHoursCalendar(model.Organization{QuietHours: h}, now); !errors.Is(err, ErrNoQuietHours) {
+			t.Errorf("QuietHoursCalendar(%+v) error = %v, want ErrNoQuietHours", h, err)
+		}
+	}
+	h := &model.OpeningHours{Timezone: "Mars/Olympus", Weekly: []model.WeeklyOpening{{Day: 1, Opens: "10:00", Closes: "12:00"}}}
+	if _, err := QuietHoursCalendar(model.Organization{QuietHours: h}, now); err == nil {
+		t.Error("QuietHoursCalendar with unknown timezone: want error")
+	}
+}
+
+func TestWriteVTimezone(t *testing.T) {
+	tests := []struct {
+		tz          string
+		from, until time.Time
+		want        []string
+	}{
+		{
+			tz:    "Europe/Moscow",
+			from:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			until: time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: []string{
+				"BEGIN:STANDARD", "DTSTART:20240101T030000", "TZOFFSETFROM:+0300", "TZOFFSETTO:+0300", "TZNAME:MSK", "END:STANDARD",
+			},
+		},
+		{
+			// отмена зимнего времени в 2014 году — переход без DST
+			tz:    "Europe/Moscow",
+			from:  time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC),
+			until: time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: []string{
+				"BEGIN:STANDARD", "DTSTART:20140101T040000", "TZOFFSETFROM:+0400", "TZOFFSETTO:+0400", "TZNAME:MSK", "END:STANDARD",
+				"BEGIN:STANDARD", "DTSTART:20141026T020000", "TZOFFSETFROM:+0400", "TZOFFSETTO:+0300", "TZNAME:MSK", "END:STANDARD",
+			},
+		},
+		{
+			tz:    "Europe/Berlin",
+			from:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			until: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: []string{
+				"BEGIN:STANDARD", "DTSTART:20240101T010000", "TZOFFSETFROM:+0100", "TZOFFSETTO:+0100", "TZNAME:CET", "END:STANDARD",
+				"BEGIN:DAYLIGHT", "DTSTART:20240331T020000", "TZOFFSETFROM:+0100", "TZOFFSETTO:+0200", "TZNAME:CEST", "END:DAYLIGHT",
+				"BEGIN:STANDARD", "DTSTART:20241027T030000", "TZOFFSETFROM:+0200", "TZOFFSETTO:+0100", "TZNAME:CET", "END:STANDARD",
+			},
+		},
+		{
+			// южное полушарие: летнее время с октября по апрель
+			tz:    "Australia/Sydney",
+			from:  time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			until: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: []string{
+				"BEGIN:STANDARD", "DTSTART:20240601T100000", "TZOFFSETFROM:+1000", "TZOFFSETTO:+1000", "TZNAME:AEST", "END:STANDARD",
+				"BEGIN:DAYLIGHT", "DTSTART:20241006T020000", "TZOFFSETFROM:+1000", "TZOFFSETTO:+1100", "TZNAME:AEDT", "END:DAYLIGHT",
+			},
+		},
+		{
+			tz:    "UTC",
+			from:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			until: time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: []string{
+				"BEGIN:STANDARD", "DTSTART:20240101T000000", "TZOFFSETFROM:+0000", "TZOFFSETTO:+0000", "TZNAME:UTC", "END:STANDARD",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tz+" "+tt.from.Format("2006"), func(t *testing.T) {
+			loc, err := time.LoadLocation(tt.tz)
+			if err != nil {
+				t.Skip(err)
+			}
+			var w calendarWriter
+			writeVTimezone(&w, tt.tz, loc, tt.from, tt.until)
+			want := append(append([]string{"BEGIN:VTIMEZONE", "TZID:" + tt.tz}, tt.want...), "END:VTIMEZONE")
+			if got := unfoldCalendar(t, w.String()); strings.Join(got, "\n") != strings.Join(want, "\n") {
+				t.Errorf("writeVTimezone(%s) =\n%s\nwant\n%s", tt.tz, strings.Join(got, "\n"), strings.Join(want, "\n"))
+			}
+		})
+	}
+}
+
+func TestIcalOffset(t *testing.T) {
+	tests := []struct {
+		seconds int
+		want    string
+	}{
+		{0, "+0000"},
+		{10800, "+0300"},
+		{19800, "+0530"},
+		{-18000, "-0500"},
+		{-12600, "-0330"},
+		{9017, "+023017"}, // местное среднее время Москвы до 1880 года
+		{-3661, "-010101"},
+	}
+	for _, tt := range tests {
+		if got := icalOffset(tt.seconds); got != tt.want {
+			t.Errorf("icalOffset(%d) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+func TestCalendarWriterLine(t *testing.T) {
+	tests := []struct {
+		name      string
+		line      string
+		wantLines int
+	}{
+		{"short", "VERSION:2.0", 1},
+		{"exactly 75 octets", strings.Repeat("a", 75), 1},
+		{"76 octets", strings.Repeat("a", 76), 2},
+		{"continuation lines hold 74 octets", strings.Repeat("a", 75+74+74), 3},
+		{"two-byte runes across the boundary", "SUMMARY:" + strings.Repeat("тишина ", 20), 4},
+		{"four-byte runes across the boundary", "X" + strings.Repeat("🤫", 40), 3},
+		{"mixed widths", "LOCATION:" + strings.Repeat("ул. Ёлочная, 5 — 🌙 ", 10), 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var w calendarWriter
+			w.line(tt.line)
+			out := w.String()
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("line does not end with CRLF: %q", out)
+			}
+			physical := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			if len(physical) != tt.wantLines {
+				t.Errorf("got %d physical lines, want %d", len(physical), tt.wantLines)
+			}
+			for i, l := range physical {
+				if len(l) > 75 {
+					t.Errorf("line %d is %d octets long", i, len(l))
+				}
+				if i > 0 && !strings.HasPrefix(l, " ") {
+					t.Errorf("continuation line %d does not start with a space: %q", i, l)
+				}
+				if !utf8.ValidString(l) {
+					t.Errorf("line %d splits a UTF-8 rune: %q", i, l)
+				}
+			}
+			if got := strings.ReplaceAll(strings.TrimSuffix(out, "\r\n"), "\r\n ", ""); got != tt.line {
+				t.Errorf("unfolded line = %q, want %q", got, tt.line)
+			}
+		})
+	}
+}
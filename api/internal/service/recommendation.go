@@ -0,0 +1,309 @@
+package service
+
+import (
+	"log"
+	"math"
+	"math/bits"
+	"sort"
+	"time"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/repository"
+)
+
+// Параметры модели коллаборативной фильтрации.
+const (
+	recNeighbours       = 20  // сколько похожих пользователей учитываем
+	recHighRating       = 4.0 // "высокая" оценка соседа
+	recPerUser          = 50  // сколько рекомендаций храним на пользователя
+	recPrefWeight       = 0.4 // вес сходства флагов UserParams
+	recRatingWeight     = 0.6 // вес сходства оценок на общих организациях
+	recMinCommonRatings = 2   // меньше общих оценок — сходство по оценкам не считаем
+)
+
+type RecommendationService struct{}
+
+func NewRecommendationService() *RecommendationService { return &RecommendationService{} }
+
+// ForUser returns precomputed recommendations (with organizations) for a user.
+func (s *RecommendationService) ForUser(userID uint, limit int) ([]model.UserRecommendation, error) {
+	return repository.ListUserRecommendations(userID, limit)
+}
+
+// RunPeriodic rebuilds the model immediately and then every interval. Blocks; run in a goroutine.
+func (s *RecommendationService) RunPeriodic(interval time.Duration) {
+	for {
+		start := time.Now()
+		if n, err := s.Build(); err != nil {
+			log.Println("warn: recommendations build failed:", err)
+		} else {
+			log.Printf("recommendations built: %d rows in %s", n, time.Since(start))
+		}
+		time.Sleep(interval)
+	}
+}
+
+// Build recomputes recommendations for every user and replaces stored ones. Returns number of rows.
+func (s *RecommendationService) Build() (int, error) {
+	params, err := repository.ListAllUserParams()
+	if err != nil {
+		return 0, err
+	}
+	ratings, err := repository.ListUserOrganizationRatings()
+	if err != nil {
+		return 0, err
+	}
+	list := buildRecommendations(params, ratings, time.Now())
+	return len(list), repository.ReplaceUserRecommendations(list)
+}
+
+// buildRecommendations is the pure part of Build: user-user neighbourhood on preference flags and ratings.
+// Each user is compared only with candidates from neighbourIndex, not with every other user.
+func buildRecommendations(params []model.UserParams, ratings []repository.UserOrganizationRating, now time.Time) []model.UserRecommendation {
+	prefs := make(map[uint][]bool, len(params))
+	for _, p := range params {
+		prefs[p.UserID] = p.Flags()
+	}
+	rated := map[uint]map[uint]float64{}
+	for _, r := range ratings {
+		if rated[r.UserID] == nil {
+			rated[r.UserID] = map[uint]float64{}
+		}
+		rated[r.UserID][r.OrganizationID] = r.Rating
+	}
+
+	users := make([]uint, 0, len(prefs)+len(rated))
+	seen := map[uint]bool{}
+	for id := range prefs {
+		seen[id] = true
+		users = append(users, id)
+	}
+	for id := range rated {
+		if !seen[id] {
+			users = append(users, id)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i] < users[j] })
+
+	type neighbour struct {
+		id  uint
+		sim float64
+	}
+	index := newNeighbourIndex(prefs, rated)
+	var out []model.UserRecommendation
+	for _, u := range users {
+		var nbs []neighbour
+		for _, v := range index.candidates(u, prefs[u], rated[u]) {
+			if sim := userSimilarity(prefs[u], prefs[v], rated[u], rated[v]); sim > 0 {
+				nbs = append(nbs, neighbour{v, sim})
+			}
+		}
+		sort.Slice(nbs, func(i, j int) bool {
+			if nbs[i].sim != nbs[j].sim {
+				return nbs[i].sim > nbs[j].sim
+			}
+			return nbs[i].id < nbs[j].id
+		})
+		if len(nbs) > recNeighbours {
+			nbs = nbs[:recNeighbours]
+		}
+
+		// прогноз оценки = взвешенное среднее оценок соседей, кандидаты — только высоко оценённые
+		type acc struct {
+			sum, wsum  float64
+			supporters uint
+		}
+		cand := map[uint]*acc{}
+		for _, nb := range nbs {
+			for orgID, rating := range rated[nb.id] {
+				if _, done := rated[u][orgID]; done {
+					continue // пользователь уже оставлял отзыв
+				}
+				a := cand[orgID]
+				if a == nil {
+					a = &acc{}
+					cand[orgID] = a
+				}
+				a.sum += nb.sim * rating
+				a.wsum += nb.sim
+				if rating >= recHighRating {
+					a.supporters++
+				}
+			}
+		}
+		var recs []model.UserRecommendation
+		for orgID, a := range cand {
+			if a.supporters == 0 || a.wsum == 0 {
+				continue
+			}
+			recs = append(recs, model.UserRecommendation{
+				UserID:         u,
+				OrganizationID: orgID,
+				Score:          a.sum / a.wsum,
+				Supporters:     a.supporters,
+				BuiltAt:        now,
+			})
+		}
+		sort.Slice(recs, func(i, j int) bool {
+			if recs[i].Score != recs[j].Score {
+				return recs[i].Score > recs[j].Score
+			}
+			return recs[i].OrganizationID < recs[j].OrganizationID
+		})
+		if len(recs) > recPerUser {
+			recs = recs[:recPerUser]
+		}
+		out = append(out, recs...)
+	}
+	return out
+}
+
+// neighbourIndex narrows the neighbour search. Positive similarity needs shared rated organizations or
+// shared preference flags: co-raters come from the organization → raters index; users with ratings are grouped
+// by their flag set, so preference-only neighbours are taken group by group in descending Jaccard order.
+type neighbourIndex struct {
+	raters map[uint][]uint // организация → оценившие её пользователи
+	groups []flagGroup
+}
+
+// flagGroup holds users with ratings and the same (non-empty) set of preference flags.
+type flagGroup struct {
+	mask  uint
+	users []uint
+}
+
+func flagMask(flags []bool) uint {
+	var m uint
+	for i, f := range flags {
+		if f {
+			m |= 1 << i
+		}
+	}
+	return m
+}
+
+func newNeighbourIndex(prefs map[uint][]bool, rated map[uint]map[uint]float64) neighbourIndex {
+	idx := neighbourIndex{raters: map[uint][]uint{}}
+	byMask := map[uint]*flagGroup{}
+	for u, orgs := range rated {
+		if len(orgs) == 0 {
+			continue
+		}
+		for orgID := range orgs {
+			idx.raters[orgID] = append(idx.raters[orgID], u)
+		}
+		if m := flagMask(prefs[u]); m != 0 {
+			if byMask[m] == nil {
+				byMask[m] = &flagGroup{mask: m}
+			}
+			byMask[m].users = append(byMask[m].users, u)
+		}
+	}
+	for _, g := range byMask {
+		sort.Slice(g.users, func(i, j int) bool { return g.users[i] < g.users[j] })
+		idx.groups = append(idx.groups, *g)
+	}
+	return idx
+}
+
+// candidates returns every co-rater of u plus preference neighbours: flag groups in descending Jaccard order
+// until at least recNeighbours users are collected, finishing the last Jaccard value. Anyone left out has
+// similarity below recNeighbours collected users (a rating term only adds to the preference one),
+// so the top-recNeighbours is the same as comparing u with everybody.
+func (idx neighbourIndex) candidates(u uint, prefs []bool, rated map[uint]float64) []uint {
+	seen := map[uint]bool{u: true}
+	var out []uint
+	for orgID := range rated {
+		for _, v := range idx.raters[orgID] {
+			if !seen[v] {
+				seen[v] = true
+				out = append(out, v)
+			}
+		}
+	}
+	mask := flagMask(prefs)
+	if mask == 0 {
+		return out
+	}
+	type scored struct {
+		jaccard float64
+		group   *flagGroup
+	}
+	var groups []scored
+	for i := range idx.groups {
+		g := &idx.groups[i]
+		if inter := bits.OnesCount(mask & g.mask); inter > 0 {
+			groups = append(groups, scored{float64(inter) / float64(bits.OnesCount(mask|g.mask)), g})
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].jaccard != groups[j].jaccard {
+			return groups[i].jaccard > groups[j].jaccard
+		}
+		return groups[i].group.mask < groups[j].group.mask
+	})
+	taken, last := 0, 0.0
+	for _, g := range groups {
+		if taken >= recNeighbours && g.jaccard < last {
+			break
+		}
+		for _, v := range g.group.users {
+			if v == u {
+				continue
+			}
+			taken++
+			if !seen[v] {
+				seen[v] = true
+				out = append(out, v)
+			}
+		}
+		last = g.jaccard
+	}
+	return out
+}
+
+// userSimilarity mixes Jaccard similarity of preference flags with rating agreement on co-rated organizations.
+func userSimilarity(prefA, prefB []bool, ratedA, ratedB map[uint]float64) float64 {
+	var pref float64
+	hasPref := false
+	if prefA != nil && prefB != nil {
+		var inter, union int
+		for i := range prefA {
+			if prefA[i] || prefB[i] {
+				union++
+				if prefA[i] && prefB[i] {
+					inter++
+				}
+			}
+		}
+		if union > 0 {
+			pref = float64(inter) / float64(union)
+			hasPref = true
+		}
+	}
+
+	// согласие оценок: 1 - средняя абсолютная разница / 4 (шкала 1..5)
+	var diff float64
+	var common int
+	for orgID, a := range ratedA {
+		if b, ok := ratedB[orgID]; ok {
+			diff += math.Abs(a - b)
+			common++
+		}
+	}
+	hasRating := common >= recMinCommonRatings
+	rating := 0.0
+	if hasRating {
+		rating = 1 - diff/float64(common)/4
+	}
+
+	switch {
+	case hasPref && hasRating:
+		return recPrefWeight*pref + recRatingWeight*rating
+	case hasRating:
+		return recRatingWeight * rating
+	case hasPref:
+		return recPrefWeight * pref
+	}
+	return 0
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"2gis-calm-map/api/internal/model"
+	"2gis-calm-map/api/internal/repository"
+)
+
+var recTestTime = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// recPair is a (user, organization, score) triple of a built recommendation.
+type recPair struct {
+	user, org uint
+	score     float64
+}
+
+func recPairs(list []model.UserRecommendation) []recPair {
+	out := make([]recPair, 0, len(list))
+	for _, r := range list {
+		out = append(out, recPair{r.UserID, r.OrganizationID, r.Score})
+	}
+	return out
+}
+
+func ratings(triples ...[3]float64) []repository.UserOrganizationRating {
+	out := make([]repository.UserOrganizationRating, 0, len(triples))
+	for _, t := range triples {
+		out = append(out, repository.UserOrganizationRating{UserID: uint(t[0]), OrganizationID: uint(t[1]), Rating: t[2]})
+	}
+	return out
+}
+
+func TestBuildRecommendations(t *testing.T) {
+	calm := model.UserParams{Calmness: true, Lighting: true}
+	tests := []struct {
+		name    string
+		params  []model.UserParams
+		ratings []repository.UserOrganizationRating
+		want    []recPair
+	}{
+		{
+			name: "agreeing co-rater recommends its highly rated organization",
+			ratings: ratings(
+				[3]float64{1, 10, 5}, [3]float64{1, 11, 4},
+				[3]float64{2, 10, 5}, [3]float64{2, 11, 4}, [3]float64{2, 12, 5},
+			),
+			want: []recPair{{1, 12, 5}},
+		},
+		{
+			name: "single common rating without preferences is below the similarity threshold",
+			ratings: ratings(
+				[3]float64{1, 10, 5},
+				[3]float64{2, 10, 5}, [3]float64{2, 12, 5},
+			),
+			want: []recPair{},
+		},
+		{
+			name: "disjoint preferences and no common ratings give no neighbours",
+			params: []model.UserParams{
+				{UserID: 1, Smell: true},
+				{UserID: 2, Calmness: true},
+			},
+			ratings: ratings([3]float64{1, 10, 5}, [3]float64{2, 12, 5}),
+			want:    []recPair{},
+		},
+		{
+			name: "shared preference flags alone make a neighbour (cold start)",
+			params: []model.UserParams{
+				{UserID: 1, Calmness: true, Lighting: true},
+				{UserID: 2, Calmness: true, Lighting: true},
+			},
+			ratings: ratings([3]float64{2, 12, 4.5}),
+			want:    []recPair{{1, 12, 4.5}},
+		},
+		{
+			name: "organizations the user already rated are not recommended",
+			ratings: ratings(
+				[3]float64{1, 10, 5}, [3]float64{1, 11, 4}, [3]float64{1, 12, 1},
+				[3]float64{2, 10, 5}, [3]float64{2, 11, 4}, [3]float64{2, 12, 5},
+			),
+			want: []recPair{},
+		},
+		{
+			name: "only organizations with a high rating by some neighbour are candidates",
+			ratings: ratings(
+				[3]float64{1, 10, 5}, [3]float64{1, 11, 4},
+				[3]float64{2, 10, 5}, [3]float64{2, 11, 4}, [3]float64{2, 12, 3},
+			),
+			want: []recPair{},
+		},
+		{
+			name:   "ordered by predicted score, ties by organization id",
+			params: []model.UserParams{withUser(calm, 1), withUser(calm, 2), withUser(calm, 3)},
+			ratings: ratings(
+				[3]float64{1, 10, 5},
+				[3]float64{2, 13, 4}, [3]float64{2, 12, 5}, [3]float64{2, 14, 5},
+				[3]float64{3, 10, 5},
+			),
+			want: []recPair{
+				{1, 12, 5}, {1, 14, 5}, {1, 13, 4},
+				{2, 10, 5},
+				{3, 12, 5}, {3, 14, 5}, {3, 13, 4},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := recPairs(buildRecommendations(tt.params, tt.ratings, recTestTime))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func withUser(p model.UserParams, id uint) model.UserParams {
+	p.UserID = id
+	return p
+}
+
+// TestNeighbourIndexMatchesFullScan checks that candidate narrowing keeps the exact top neighbours:
+// results equal those of comparing every pair of users.
+func TestNeighbourIndexMatchesFullScan(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	var params []model.UserParams
+	var list []repository.UserOrganizationRating
+	for u := uint(1); u <= 300; u++ {
+		if rng.Intn(3) > 0 {
+			// мало различных наборов флагов — много равных Jaccard, проверяем добор групп целиком
+			params = append(params, model.UserParams{UserID: u, Calmness: rng.Intn(2) == 0, Lighting: rng.Intn(2) == 0, Smell: rng.Intn(3) == 0})
+		}
+		for k := rng.Intn(6); k > 0; k-- {
+			list = append(list, repository.UserOrganizationRating{UserID: u, OrganizationID: uint(1 + rng.Intn(80)), Rating: float64(1 + rng.Intn(5))})
+		}
+	}
+	list = dedupeRatings(list)
+
+	got := buildRecommendations(params, list, recTestTime)
+	want := fullScanRecommendations(params, list)
+	if !reflect.DeepEqual(recPairs(got), want) {
+		t.Fatalf("narrowed neighbours differ from full scan:\ngot  %v\nwant %v", recPairs(got), want)
+	}
+}
+
+func dedupeRatings(list []repository.UserOrganizationRating) []repository.UserOrganizationRating {
+	seen := map[[2]uint]bool{}
+	out := list[:0]
+	for _, r := range list {
+		key := [2]uint{r.UserID, r.OrganizationID}
+		if !seen[key] {
+			seen[key] = true
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// fullScanRecommendations is the reference O(users²) neighbourhood: every user compared with every other.
+func fullScanRecommendations(params []model.UserParams, list []repository.UserOrganizationRating) []recPair {
+	prefs := map[uint][]bool{}
+	for _, p := range params {
+		prefs[p.UserID] = p.Flags()
+	}
+	rated := map[uint]map[uint]float64{}
+	for _, r := range list {
+		if rated[r.UserID] == nil {
+			rated[r.UserID] = map[uint]float64{}
+		}
+		rated[r.UserID][r.OrganizationID] = r.Rating
+	}
+	seen := map[uint]bool{}
+	var users []uint
+	for id := range prefs {
+		seen[id] = true
+		users = append(users, id)
+	}
+	for id := range rated {
+		if !seen[id] {
+			users = append(users, id)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i] < users[j] })
+
+	out := []recPair{}
+	for _, u := range users {
+		type nb struct {
+			id  uint
+			sim float64
+		}
+		var nbs []nb
+		for _, v := range users {
+			if v == u || len(rated[v]) == 0 {
+				continue
+			}
+			if sim := userSimilarity(prefs[u], prefs[v], rated[u], rated[v]); sim > 0 {
+				nbs = append(nbs, nb{v, sim})
+			}
+		}
+		sort.Slice(nbs, func(i, j int) bool {
+			if nbs[i].sim != nbs[j].sim {
+				return nbs[i].sim > nbs[j].sim
+			}
+			return nbs[i].id < nbs[j].id
+		})
+		if len(nbs) > recNeighbours {
+			nbs = nbs[:recNeighbours]
+		}
+		sum, wsum, high := map[uint]float64{}, map[uint]float64{}, map[uint]bool{}
+		for _, n := range nbs {
+			for orgID, r := range rated[n.id] {
+				if _, done := rated[u][orgID]; done {
+					continue
+				}
+				sum[orgID] += n.sim * r
+				wsum[orgID] += n.sim
+				if r >= recHighRating {
+					high[orgID] = true
+				}
+			}
+		}
+		var recs []recPair
+		for orgID := range sum {
+			if high[orgID] && wsum[orgID] > 0 {
+				recs = append(recs, recPair{u, orgID, sum[orgID] / wsum[orgID]})
+			}
+		}
+		sort.Slice(recs, func(i, j int) bool {
+			if recs[i].score != recs[j].score {
+				return recs[i].score > recs[j].score
+			}
+			return recs[i].org < recs[j].org
+		})
+		if len(recs) > recPerUser {
+			recs = recs[:recPerUser]
+		}
+		out = append(out, recs...)
+	}
+	return out
+}
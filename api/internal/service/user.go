@@ -31,3 +31,7 @@ func (s *UserService) AuthenticateUser(email, password string) (model.User, erro
 	}
 	return user, nil
 }
+
+func (s *UserService) GetByEmail(email string) (model.User, error) {
+	return repository.GetUserByEmail(email)
+}